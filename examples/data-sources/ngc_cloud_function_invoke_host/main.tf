@@ -0,0 +1,4 @@
+data "ngc_cloud_function_invoke_host" "example" {
+  function_id = "98370588-40c4-4369-b965-12679ce05f47"
+  version_id  = "59a6193e-d0ed-4abb-8f47-7dd46480f126"
+}
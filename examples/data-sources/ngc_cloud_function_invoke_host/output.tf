@@ -0,0 +1,9 @@
+output "invoke_host" {
+  description = "The NVCF host serving function invocations"
+  value       = data.ngc_cloud_function_invoke_host.example.invoke_host
+}
+
+output "invoke_url" {
+  description = "The invocation URL of the function version"
+  value       = data.ngc_cloud_function_invoke_host.example.invoke_url
+}
@@ -0,0 +1,14 @@
+resource "ngc_cloud_function_deployment" "deployment" {
+  function_id = "033c9664-f5b0-4bd2-8918-5aab085fc8db"
+  version_id  = "f0cc4c95-108c-471a-b52c-a2bd5c0024c2"
+  deployment_specifications = [
+    {
+      gpu_type                = "L40"
+      instance_type           = "gl40_1.br20_2xlarge"
+      clusters                = ["mock-cluster"]
+      max_instances           = 1
+      min_instances           = 1
+      max_request_concurrency = 1
+    }
+  ]
+}
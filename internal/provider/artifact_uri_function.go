@@ -0,0 +1,110 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	custom_planmodifier "gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/planmodifier"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ArtifactUriFunction{}
+
+func NewArtifactUriFunction() function.Function {
+	return &ArtifactUriFunction{}
+}
+
+// ArtifactUriFunction builds the registry URI of an NGC model from its name and version.
+type ArtifactUriFunction struct{}
+
+func (f *ArtifactUriFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "artifact_uri"
+}
+
+func (f *ArtifactUriFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the registry URI of an NGC model",
+		MarkdownDescription: "Builds the fully-qualified registry URI of an NGC model, e.g. `https://api.ngc.nvidia.com/v2/org/nvidia/team/nemo/models/gemma_2b_base/1.1/files`, " +
+			"for the `models` of a cloud function. Functions don't see the provider configuration, so the host is `https://api.ngc.nvidia.com` " +
+			"unless given, e.g. `provider::ngc::artifact_uri(\"nvidia/nemo/gemma_2b_base\", \"1.1\", \"https://api.stg.ngc.nvidia.com\")`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Model name in the `org/team/name` format, or `org/name` for a model without a team",
+			},
+			function.StringParameter{
+				Name:                "version",
+				MarkdownDescription: "Model version",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "host",
+			MarkdownDescription: "Optional registry host, defaults to `https://api.ngc.nvidia.com`",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ArtifactUriFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name, version string
+	var hosts []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name, &version, &hosts))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if len(hosts) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("expected at most one host, got %d", len(hosts)))
+		return
+	}
+
+	host := custom_planmodifier.DEFAULT_ARTIFACT_HOST
+	if len(hosts) == 1 && hosts[0] != "" {
+		host = strings.TrimSuffix(hosts[0], "/")
+	}
+
+	artifactPath, err := artifactUriPath(name, version)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, fmt.Sprintf("%s/%s", host, artifactPath)))
+}
+
+// artifactUriPath returns the registry path of a model, without the host.
+func artifactUriPath(name string, version string) (string, error) {
+	segments := strings.Split(strings.Trim(name, "/"), "/")
+	for _, segment := range segments {
+		if segment == "" {
+			return "", fmt.Errorf("invalid artifact name %q, expected org/team/name or org/name", name)
+		}
+	}
+
+	if version == "" {
+		return "", fmt.Errorf("missing version of artifact %q", name)
+	}
+
+	switch len(segments) {
+	case 2:
+		return fmt.Sprintf("v2/org/%s/models/%s/%s/files", segments[0], segments[1], version), nil
+	case 3:
+		return fmt.Sprintf("v2/org/%s/team/%s/models/%s/%s/files", segments[0], segments[1], segments[2], version), nil
+	default:
+		return "", fmt.Errorf("invalid artifact name %q, expected org/team/name or org/name", name)
+	}
+}
@@ -0,0 +1,112 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestArtifactUriFunction_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		hosts       []string
+		artifact    string
+		version     string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "org team and name",
+			artifact: "nvidia/nemo/gemma_2b_base",
+			version:  "1.1",
+			expected: "https://api.ngc.nvidia.com/v2/org/nvidia/team/nemo/models/gemma_2b_base/1.1/files",
+		},
+		{
+			name:     "short name without team",
+			artifact: "nvidia/gemma_2b_base",
+			version:  "1.1",
+			expected: "https://api.ngc.nvidia.com/v2/org/nvidia/models/gemma_2b_base/1.1/files",
+		},
+		{
+			name:     "given host",
+			hosts:    []string{"https://api.stg.ngc.nvidia.com/"},
+			artifact: "nvidia/nemo/gemma_2b_base",
+			version:  "1.1",
+			expected: "https://api.stg.ngc.nvidia.com/v2/org/nvidia/team/nemo/models/gemma_2b_base/1.1/files",
+		},
+		{
+			name:        "more than one host",
+			hosts:       []string{"https://api.stg.ngc.nvidia.com", "https://api.ngc.nvidia.com"},
+			artifact:    "nvidia/nemo/gemma_2b_base",
+			version:     "1.1",
+			expectError: true,
+		},
+		{
+			name:        "name without org",
+			artifact:    "gemma_2b_base",
+			version:     "1.1",
+			expectError: true,
+		},
+		{
+			name:        "missing version",
+			artifact:    "nvidia/nemo/gemma_2b_base",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostTypes := make([]attr.Type, 0, len(tt.hosts))
+			hostValues := make([]attr.Value, 0, len(tt.hosts))
+			for _, host := range tt.hosts {
+				hostTypes = append(hostTypes, types.StringType)
+				hostValues = append(hostValues, types.StringValue(host))
+			}
+
+			f := NewArtifactUriFunction()
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.artifact),
+					types.StringValue(tt.version),
+					types.TupleValueMust(hostTypes, hostValues),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			f.Run(context.Background(), req, resp)
+
+			if tt.expectError {
+				if resp.Error == nil {
+					t.Fatalf("expected an error, got %s", resp.Result.Value())
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tt.expected)) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NvidiaCloudFunctionActiveVersionDataSource{}
+
+func NewNvidiaCloudFunctionActiveVersionDataSource() datasource.DataSource {
+	return &NvidiaCloudFunctionActiveVersionDataSource{}
+}
+
+// NvidiaCloudFunctionActiveVersionDataSource defines the data source implementation.
+type NvidiaCloudFunctionActiveVersionDataSource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionActiveVersionDataSourceModel describes the data source data model.
+type NvidiaCloudFunctionActiveVersionDataSourceModel struct {
+	FunctionID   types.String `tfsdk:"function_id"`
+	VersionID    types.String `tfsdk:"version_id"`
+	FunctionName types.String `tfsdk:"function_name"`
+	InferenceUrl types.String `tfsdk:"inference_url"`
+	InvokeUrl    types.String `tfsdk:"invoke_url"`
+}
+
+func (d *NvidiaCloudFunctionActiveVersionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function_active_version"
+}
+
+func (d *NvidiaCloudFunctionActiveVersionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves the single ACTIVE version of a function, i.e. the version currently serving its traffic. Fails when the function has no or multiple ACTIVE versions.",
+		Attributes: map[string]schema.Attribute{
+			"function_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Function ID",
+			},
+			"version_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the ACTIVE Function Version",
+			},
+			"function_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Function name",
+			},
+			"inference_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Service endpoint Path of the ACTIVE Function Version",
+			},
+			"invoke_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Invocation URL pinned to the ACTIVE Function Version",
+			},
+		},
+	}
+}
+
+func (d *NvidiaCloudFunctionActiveVersionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = ngcClient.NVCFClient()
+}
+
+func (d *NvidiaCloudFunctionActiveVersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NvidiaCloudFunctionActiveVersionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	function, err := d.client.FindActiveNvidiaCloudFunctionVersion(ctx, data.FunctionID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to resolve the ACTIVE Cloud Function version",
+			err.Error(),
+		)
+		return
+	}
+
+	data.VersionID = types.StringValue(function.VersionID)
+	data.FunctionName = types.StringValue(function.Name)
+	data.InferenceUrl = types.StringValue(function.InferenceURL)
+	data.InvokeUrl = types.StringValue(fmt.Sprintf("%s/v2/nvcf/pexec/functions/%s/versions/%s", d.client.NvcfInvokeHost(ctx), function.ID, function.VersionID))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,59 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build !unittest
+// +build !unittest
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/testutils"
+)
+
+func TestAccCloudFunctionActiveVersionDataSource_Success(t *testing.T) {
+	var datasourceName = testutils.TestCommonPrefix + "active-version-datasource"
+	var datasourceFullPath = fmt.Sprintf("data.ngc_cloud_function_active_version.%s", datasourceName)
+
+	functionInfo := testutils.CreateContainerFunction(t)
+	defer testutils.DeleteFunction(t, functionInfo.Function.ID, functionInfo.Function.VersionID)
+
+	testutils.CreateDeployment(t, functionInfo.Function.ID, functionInfo.Function.VersionID, "")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					err := testutils.TestNVCFClient.WaitingDeploymentCompleted(testutils.Ctx, functionInfo.Function.ID, functionInfo.Function.VersionID)
+					if err != nil {
+						t.Fatalf("Unable to wait for function deployment: %s", err.Error())
+					}
+				},
+				Config: fmt.Sprintf(`
+					data "ngc_cloud_function_active_version" "%s" {
+						function_id = "%s"
+					}
+				`, datasourceName, functionInfo.Function.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceFullPath, "version_id", functionInfo.Function.VersionID),
+					resource.TestCheckResourceAttr(datasourceFullPath, "function_name", testutils.TestContainerFunctionName),
+					resource.TestCheckResourceAttr(datasourceFullPath, "inference_url", testutils.TestContainerInferenceUrl),
+					resource.TestCheckResourceAttr(datasourceFullPath, "invoke_url",
+						fmt.Sprintf("%s/v2/nvcf/pexec/functions/%s/versions/%s", testutils.TestNVCFClient.NvcfInvokeHost(testutils.Ctx), functionInfo.Function.ID, functionInfo.Function.VersionID)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,132 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NvidiaCloudFunctionCapacityDataSource{}
+
+func NewNvidiaCloudFunctionCapacityDataSource() datasource.DataSource {
+	return &NvidiaCloudFunctionCapacityDataSource{}
+}
+
+// NvidiaCloudFunctionCapacityDataSource defines the data source implementation.
+type NvidiaCloudFunctionCapacityDataSource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionCapacityDataSourceModel describes the data source data model.
+type NvidiaCloudFunctionCapacityDataSourceModel struct {
+	GpuType       types.String `tfsdk:"gpu_type"`
+	Backend       types.String `tfsdk:"backend"`
+	InstanceType  types.String `tfsdk:"instance_type"`
+	Offered       types.Bool   `tfsdk:"offered"`
+	InstanceTypes types.List   `tfsdk:"instance_types"`
+}
+
+func (d *NvidiaCloudFunctionCapacityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function_capacity"
+}
+
+func (d *NvidiaCloudFunctionCapacityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tells whether NVCF offers the org instances of a GPU, and optionally an instance type, in its cluster groups. " +
+			"NVCF doesn't report the instances left free in a cluster group, a deployment may still fail when all of them are in use.",
+		Attributes: map[string]schema.Attribute{
+			"gpu_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "GPU Type, e.g. \"L40\"",
+			},
+			"backend": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Cluster group to place the instances in, any cluster group of the org when unset",
+			},
+			"instance_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Instance Type to place, any instance type of the GPU when unset",
+			},
+			"offered": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether a cluster group offers the GPU, and instance type when set, to the org. It doesn't tell whether instances are left free",
+			},
+			"instance_types": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Instance Types of the GPU the org can deploy to",
+			},
+		},
+	}
+}
+
+func (d *NvidiaCloudFunctionCapacityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = ngcClient.NVCFClient()
+}
+
+func (d *NvidiaCloudFunctionCapacityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NvidiaCloudFunctionCapacityDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listClusterGroupsResponse, err := d.client.ListClusterGroups(ctx)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to list Cloud Function cluster groups",
+			err.Error(),
+		)
+		return
+	}
+
+	instanceTypes := utils.AvailableInstanceTypes(listClusterGroupsResponse.ClusterGroups, data.GpuType.ValueString(), data.Backend.ValueString())
+
+	if data.InstanceType.IsNull() {
+		data.Offered = types.BoolValue(len(instanceTypes) > 0)
+	} else {
+		data.Offered = types.BoolValue(slices.Contains(instanceTypes, data.InstanceType.ValueString()))
+	}
+
+	instanceTypesList, diags := types.ListValueFrom(ctx, types.StringType, instanceTypes)
+	resp.Diagnostics.Append(diags...)
+	data.InstanceTypes = instanceTypesList
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
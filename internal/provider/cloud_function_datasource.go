@@ -14,6 +14,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -59,6 +61,14 @@ type NvidiaCloudFunctionDataSourceModel struct {
 	AuthorizedParties        types.Set                               `tfsdk:"authorized_parties"`
 	Telemetries              types.Object                            `tfsdk:"telemetries"`
 	GracefulDeletion         types.Bool                              `tfsdk:"graceful_deletion"`
+	IsActive                 types.Bool                              `tfsdk:"is_active"`
+	Status                   types.String                            `tfsdk:"status"`
+	Secrets                  types.Set                               `tfsdk:"secrets"`
+	ActiveInstances          types.List                              `tfsdk:"active_instances"`
+	CreatedAt                types.String                            `tfsdk:"created_at"`
+	VersionAgeDays           types.Int64                             `tfsdk:"version_age_days"`
+	IncludeDeployment        types.Bool                              `tfsdk:"include_deployment"`
+	DeploymentHealth         types.List                              `tfsdk:"deployment_health"`
 }
 
 func (d *NvidiaCloudFunctionDataSource) updateNvidiaCloudFunctionDataSourceModel(
@@ -81,8 +91,8 @@ func (d *NvidiaCloudFunctionDataSource) updateNvidiaCloudFunctionDataSourceModel
 		data.InferenceUrl = types.StringValue(functionInfo.InferenceURL)
 	}
 
-	if functionInfo.NcaID != "" {
-		data.NcaId = types.StringValue(functionInfo.NcaID)
+	if ncaID := functionNcaID(functionInfo, functionDeployment); ncaID != "" {
+		data.NcaId = types.StringValue(ncaID)
 	}
 
 	if functionInfo.Name != "" {
@@ -109,14 +119,46 @@ func (d *NvidiaCloudFunctionDataSource) updateNvidiaCloudFunctionDataSourceModel
 		data.ContainerArgs = types.StringValue(functionInfo.ContainerArgs)
 	}
 
+	// NVCF may omit the type of unary functions, consumers choosing between streaming and unary invocation
+	// can then rely on any other value than STREAMING being unary.
 	if functionInfo.FunctionType != "" {
 		data.FunctionType = types.StringValue(functionInfo.FunctionType)
+	} else if data.FunctionType.IsNull() || data.FunctionType.IsUnknown() {
+		data.FunctionType = types.StringValue("DEFAULT")
 	}
 
 	if functionInfo.Description != "" {
 		data.Description = types.StringValue(functionInfo.Description)
 	}
 
+	data.IsActive = types.BoolValue(functionDeployment.FunctionStatus == "ACTIVE")
+
+	if functionInfo.Status != "" {
+		data.Status = types.StringValue(functionInfo.Status)
+	}
+
+	if !functionInfo.CreatedAt.IsZero() {
+		data.CreatedAt = types.StringValue(functionInfo.CreatedAt.UTC().Format(time.RFC3339))
+		data.VersionAgeDays = types.Int64Value(versionAgeDays(functionInfo.CreatedAt, time.Now()))
+	} else {
+		data.CreatedAt = types.StringNull()
+		data.VersionAgeDays = types.Int64Null()
+	}
+
+	deploymentHealth := make([]NvidiaCloudFunctionDeploymentHealthModel, 0)
+	for _, v := range functionDeployment.Health() {
+		deploymentHealth = append(deploymentHealth, NvidiaCloudFunctionDeploymentHealthModel{
+			Gpu:          types.StringValue(v.Gpu),
+			Backend:      types.StringValue(v.Backend),
+			InstanceType: types.StringValue(v.InstanceType),
+			Error:        types.StringValue(v.Error),
+			SisRequestID: types.StringValue(v.SisRequestID),
+		})
+	}
+	deploymentHealthListType, deploymentHealthListTypeDiag := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: (&NvidiaCloudFunctionDeploymentHealthModel{}).attrTypes()}, deploymentHealth)
+	diag.Append(deploymentHealthListTypeDiag...)
+	data.DeploymentHealth = deploymentHealthListType
+
 	if functionDeployment.DeploymentSpecifications != nil {
 		deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0)
 
@@ -130,9 +172,12 @@ func (d *NvidiaCloudFunctionDataSource) updateNvidiaCloudFunctionDataSourceModel
 				MaxRequestConcurrency: types.Int64Value(int64(v.MaxRequestConcurrency)),
 			}
 
+			// Container functions have no configuration, keep it explicitly null so reads stay stable.
 			if v.Configuration != nil {
 				configuration, _ := json.Marshal(v.Configuration)
 				deploymentSpecification.Configuration = types.StringValue(string(configuration))
+			} else {
+				deploymentSpecification.Configuration = types.StringNull()
 			}
 
 			if v.Clusters != nil {
@@ -162,12 +207,39 @@ func (d *NvidiaCloudFunctionDataSource) updateNvidiaCloudFunctionDataSourceModel
 	diag.Append(tagsSetFromDiag...)
 	data.Tags = tags
 
-	data.Health = &NvidiaCloudFunctionResourceHealthModel{
-		Protocol:           types.StringValue(functionInfo.Health.Protocol),
-		Uri:                types.StringValue(functionInfo.Health.URI),
-		Port:               types.Int64Value(int64(functionInfo.Health.Port)),
-		Timeout:            types.StringValue(functionInfo.Health.Timeout),
-		ExpectedStatusCode: types.Int64Value(int64(functionInfo.Health.ExpectedStatusCode)),
+	// NVCF only returns the names of the secrets, never their values.
+	secrets, secretsSetFromDiag := types.SetValueFrom(ctx, types.StringType, append(make([]string, 0), functionInfo.Secrets...))
+	diag.Append(secretsSetFromDiag...)
+	data.Secrets = secrets
+
+	activeInstances := make([]NvidiaCloudFunctionActiveInstanceModel, 0, len(functionInfo.ActiveInstances))
+	for _, v := range functionInfo.ActiveInstances {
+		activeInstances = append(activeInstances, NvidiaCloudFunctionActiveInstanceModel{
+			InstanceID:        types.StringValue(v.InstanceID),
+			InstanceStatus:    types.StringValue(v.InstanceStatus),
+			InstanceType:      types.StringValue(v.InstanceType),
+			Gpu:               types.StringValue(v.Gpu),
+			Backend:           types.StringValue(v.Backend),
+			Location:          types.StringValue(v.Location),
+			InstanceCreatedAt: types.StringValue(v.InstanceCreatedAt.UTC().Format(time.RFC3339)),
+			InstanceUpdatedAt: types.StringValue(v.InstanceUpdatedAt.UTC().Format(time.RFC3339)),
+		})
+	}
+	activeInstancesListType, activeInstancesListTypeDiag := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: (&NvidiaCloudFunctionActiveInstanceModel{}).attrTypes()}, activeInstances)
+	diag.Append(activeInstancesListTypeDiag...)
+	data.ActiveInstances = activeInstancesListType
+
+	// Functions created before the health block was introduced may only have a health_uri.
+	if functionInfo.Health != nil {
+		data.Health = &NvidiaCloudFunctionResourceHealthModel{
+			Protocol:           types.StringValue(functionInfo.Health.Protocol),
+			Uri:                types.StringValue(functionInfo.Health.URI),
+			Port:               types.Int64Value(int64(functionInfo.Health.Port)),
+			Timeout:            types.StringValue(functionInfo.Health.Timeout),
+			ExpectedStatusCode: types.Int64Value(int64(functionInfo.Health.ExpectedStatusCode)),
+		}
+	} else {
+		data.Health = nil
 	}
 
 	if functionInfo.ContainerEnvironment != nil {
@@ -245,6 +317,14 @@ func (d *NvidiaCloudFunctionDataSource) updateNvidiaCloudFunctionDataSourceModel
 	data.GracefulDeletion = types.BoolValue(false)
 }
 
+// versionAgeDays returns the full days elapsed between the creation of a version and now.
+func versionAgeDays(createdAt time.Time, now time.Time) int64 {
+	if now.Before(createdAt) {
+		return 0
+	}
+	return int64(now.Sub(createdAt) / (24 * time.Hour))
+}
+
 func (d *NvidiaCloudFunctionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_cloud_function"
 }
@@ -317,7 +397,7 @@ func (d *NvidiaCloudFunctionDataSource) Schema(ctx context.Context, req datasour
 				Computed:            true,
 			},
 			"function_type": schema.StringAttribute{
-				MarkdownDescription: "Optional function type, used to indicate a STREAMING function. Defaults is \"DEFAULT\".",
+				MarkdownDescription: "Function type, \"STREAMING\" for a function streaming its responses, which must be invoked accordingly, \"DEFAULT\" otherwise.",
 				Optional:            true,
 				Computed:            true,
 			},
@@ -333,6 +413,100 @@ func (d *NvidiaCloudFunctionDataSource) Schema(ctx context.Context, req datasour
 				Optional:            true,
 				Computed:            true,
 			},
+			"include_deployment": schema.BoolAttribute{
+				MarkdownDescription: "Read the deployment of the function version. Set to \"false\" for metadata only lookups, `deployment_specifications`, `deployment_health` and `is_active` are then left unset. Default is \"true\"",
+				Optional:            true,
+				Computed:            true,
+			},
+			"deployment_health": schema.ListNestedAttribute{
+				MarkdownDescription: "Health NVCF reports for the deployment, e.g. why instances of a GPU and instance type failed to start",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"gpu": schema.StringAttribute{
+							MarkdownDescription: "GPU Type",
+							Computed:            true,
+						},
+						"backend": schema.StringAttribute{
+							MarkdownDescription: "NVCF Backend",
+							Computed:            true,
+						},
+						"instance_type": schema.StringAttribute{
+							MarkdownDescription: "NVCF Backend Instance Type",
+							Computed:            true,
+						},
+						"error": schema.StringAttribute{
+							MarkdownDescription: "Error reported for the GPU and instance type, e.g. a failed image pull",
+							Computed:            true,
+						},
+						"sis_request_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the NVCF request placing the instances",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"is_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the function version is deployed and ACTIVE",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Function version status, e.g. \"ACTIVE\", \"DEPLOYING\" or \"INACTIVE\"",
+				Computed:            true,
+			},
+			"secrets": schema.SetAttribute{
+				MarkdownDescription: "Names of the secrets the function expects. Secret values are never returned.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Function version creation timestamp",
+				Computed:            true,
+			},
+			"version_age_days": schema.Int64Attribute{
+				MarkdownDescription: "Full days elapsed since the function version was created, relative to the time the data source is read",
+				Computed:            true,
+			},
+			"active_instances": schema.ListNestedAttribute{
+				MarkdownDescription: "Instances currently running the function version, e.g. to confirm the deployed capacity",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"instance_id": schema.StringAttribute{
+							MarkdownDescription: "Instance ID",
+							Computed:            true,
+						},
+						"instance_status": schema.StringAttribute{
+							MarkdownDescription: "Instance status, e.g. \"ACTIVE\"",
+							Computed:            true,
+						},
+						"instance_type": schema.StringAttribute{
+							MarkdownDescription: "NVCF Backend Instance Type",
+							Computed:            true,
+						},
+						"gpu": schema.StringAttribute{
+							MarkdownDescription: "GPU Type",
+							Computed:            true,
+						},
+						"backend": schema.StringAttribute{
+							MarkdownDescription: "NVCF Backend",
+							Computed:            true,
+						},
+						"location": schema.StringAttribute{
+							MarkdownDescription: "Location of the instance",
+							Computed:            true,
+						},
+						"instance_created_at": schema.StringAttribute{
+							MarkdownDescription: "Instance creation timestamp",
+							Computed:            true,
+						},
+						"instance_updated_at": schema.StringAttribute{
+							MarkdownDescription: "Instance last update timestamp",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -393,16 +567,23 @@ func (d *NvidiaCloudFunctionDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
-	readNvidiaCloudFunctionDeploymentResponse, err := d.client.ReadNvidiaCloudFunctionDeployment(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+	if data.IncludeDeployment.IsNull() || data.IncludeDeployment.IsUnknown() {
+		data.IncludeDeployment = types.BoolValue(true)
+	}
 
-	if err != nil {
-		// FIXME: extract error messsage to constants.
-		if err.Error() != "failed to find function deployment" {
-			resp.Diagnostics.AddError(
-				"Failed to read Cloud Function deployment",
-				err.Error(),
-			)
-			return
+	readNvidiaCloudFunctionDeploymentResponse := &utils.ReadNvidiaCloudFunctionDeploymentResponse{}
+	if data.IncludeDeployment.ValueBool() {
+		readNvidiaCloudFunctionDeploymentResponse, err = d.client.ReadNvidiaCloudFunctionDeployment(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+
+		if err != nil {
+			// FIXME: extract error messsage to constants.
+			if !strings.Contains(err.Error(), "failed to find function deployment") {
+				resp.Diagnostics.AddError(
+					"Failed to read Cloud Function deployment",
+					err.Error(),
+				)
+				return
+			}
 		}
 	}
 
@@ -419,6 +600,12 @@ func (d *NvidiaCloudFunctionDataSource) Read(ctx context.Context, req datasource
 
 	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &resp.Diagnostics, &data, &functionVersion, &readNvidiaCloudFunctionDeploymentResponse.Deployment, getFunctionAuthorizationResponse.Function.AuthorizedParties)
 
+	// Without the deployment, whether the version is ACTIVE and its health aren't known.
+	if !data.IncludeDeployment.ValueBool() {
+		data.IsActive = types.BoolNull()
+		data.DeploymentHealth = types.ListNull(types.ObjectType{AttrTypes: (&NvidiaCloudFunctionDeploymentHealthModel{}).attrTypes()})
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -53,7 +53,13 @@ func TestAccCloudFunctionDataSource_HelmBasedFunction(t *testing.T) {
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "inference_port", strconv.Itoa(testutils.TestHelmServicePort)),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "inference_url", testutils.TestHelmInferenceUrl),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health_uri", testutils.TestHelmHealthUri),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.protocol", "HTTP"),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.uri", testutils.TestHelmHealthUri),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.port", strconv.Itoa(testutils.TestHelmServicePort)),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.timeout", "PT10S"),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.expected_status_code", "200"),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "api_body_format", testutils.TestHelmAPIFormat),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "function_type", testutils.TestFunctionType),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "nca_id", testutils.TestNcaID),
 					resource.TestCheckNoResourceAttr(testCloudFunctionDatasourceFullPath, "container_image"),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "deployment_specifications.0.gpu_type", testutils.TestGpuType),
@@ -98,7 +104,13 @@ func TestAccCloudFunctionDataSource_ContainerBasedFunction(t *testing.T) {
 					resource.TestCheckNoResourceAttr(testCloudFunctionDatasourceFullPath, "helm_chart_service_name"),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "inference_url", testutils.TestContainerInferenceUrl),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health_uri", testutils.TestContainerHealthUri),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.protocol", "HTTP"),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.uri", testutils.TestContainerHealthUri),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.port", strconv.Itoa(testutils.TestContainerPort)),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.timeout", "PT10S"),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "health.expected_status_code", "200"),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "api_body_format", testutils.TestContainerAPIFormat),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "function_type", testutils.TestFunctionType),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "nca_id", testutils.TestNcaID),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "container_image", testutils.TestContainerUri),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "inference_port", strconv.Itoa(testutils.TestContainerPort)),
@@ -113,6 +125,26 @@ func TestAccCloudFunctionDataSource_ContainerBasedFunction(t *testing.T) {
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "tags.1", testutils.TestTags[1]),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "container_environment.0.key", testutils.TestContainerEnvironmentVariables[0].Key),
 					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "container_environment.0.value", testutils.TestContainerEnvironmentVariables[0].Value),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "secrets.#", "0"),
+				),
+			},
+			{
+				PreConfig: func() {
+					err := testutils.TestNVCFClient.WaitingDeploymentCompleted(testutils.Ctx, functionInfo.Function.ID, functionInfo.Function.VersionID)
+					if err != nil {
+						t.Fatalf("Unable to wait for function deployment: %s", err.Error())
+					}
+				},
+				Config: fmt.Sprintf(`
+						data "ngc_cloud_function" "%s" {
+						function_id = "%s"
+						version_id  = "%s"
+						}
+						`,
+					testCloudFunctionDatasourceName, functionInfo.Function.ID, functionInfo.Function.VersionID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "is_active", "true"),
+					resource.TestCheckResourceAttr(testCloudFunctionDatasourceFullPath, "status", "ACTIVE"),
 				),
 			},
 		},
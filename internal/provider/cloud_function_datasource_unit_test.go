@@ -0,0 +1,397 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestUpdateNvidiaCloudFunctionDataSourceModel_DeploymentWithoutConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionDataSource{}
+	functionInfo := &utils.NvidiaCloudFunctionInfo{
+		ID:             "mock-function-id",
+		VersionID:      "mock-version-id",
+		ContainerImage: "nvcr.io/mock-org/mock-image:latest",
+		Health:         &utils.NvidiaCloudFunctionHealth{Protocol: "HTTP", URI: "/health", Port: 8000, Timeout: "PT10S", ExpectedStatusCode: 200},
+	}
+	functionDeployment := &utils.NvidiaCloudFunctionDeployment{
+		FunctionStatus: "ACTIVE",
+		DeploymentSpecifications: []utils.NvidiaCloudFunctionDeploymentSpecification{
+			{Gpu: "L40", InstanceType: "gl40_1.br20_2xlarge", MinInstances: 1, MaxInstances: 1, MaxRequestConcurrency: 1},
+		},
+	}
+
+	var diags diag.Diagnostics
+	var first, second NvidiaCloudFunctionDataSourceModel
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &first, functionInfo, functionDeployment, nil)
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &second, functionInfo, functionDeployment, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, first.DeploymentSpecifications.Equal(second.DeploymentSpecifications))
+
+	var specs []NvidiaCloudFunctionResourceDeploymentSpecificationModel
+	diags.Append(first.DeploymentSpecifications.ElementsAs(ctx, &specs, false)...)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Len(t, specs, 1)
+	assert.True(t, specs[0].Configuration.IsNull())
+	assert.True(t, specs[0].Clusters.IsNull())
+}
+
+func TestUpdateNvidiaCloudFunctionDataSourceModel_SecretNames(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionDataSource{}
+	functionInfo := &utils.NvidiaCloudFunctionInfo{
+		ID:        "mock-function-id",
+		VersionID: "mock-version-id",
+		Health:    &utils.NvidiaCloudFunctionHealth{},
+		Secrets:   []string{"MOCK_SECRET_1", "MOCK_SECRET_2"},
+	}
+
+	var diags diag.Diagnostics
+	var data NvidiaCloudFunctionDataSourceModel
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	var secrets []string
+	diags.Append(data.Secrets.ElementsAs(ctx, &secrets, false)...)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.ElementsMatch(t, []string{"MOCK_SECRET_1", "MOCK_SECRET_2"}, secrets)
+
+	// Functions without secrets still expose a known, empty set.
+	functionInfo.Secrets = nil
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.False(t, data.Secrets.IsNull())
+	assert.Empty(t, data.Secrets.Elements())
+}
+
+func TestUpdateNvidiaCloudFunctionDataSourceModel_HelmChartServiceName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionDataSource{}
+
+	var functionInfo utils.NvidiaCloudFunctionInfo
+	err := json.Unmarshal([]byte(`{"id": "mock-function-id", "versionId": "mock-version-id", "helmChart": "https://helm.ngc.nvidia.com/mock-org/charts/mock-chart-1.0.0.tgz", "helmChartServiceName": "entry"}`), &functionInfo)
+	assert.NoError(t, err)
+
+	var diags diag.Diagnostics
+	var data NvidiaCloudFunctionDataSourceModel
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, &functionInfo, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.StringValue("entry"), data.HelmChartServiceName)
+}
+
+func TestUpdateNvidiaCloudFunctionDataSourceModel_Health(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionDataSource{}
+	functionInfo := &utils.NvidiaCloudFunctionInfo{
+		ID:        "mock-function-id",
+		VersionID: "mock-version-id",
+		HealthURI: "/health",
+		Health:    &utils.NvidiaCloudFunctionHealth{Protocol: "HTTP", URI: "/health", Port: 8000, Timeout: "PT10S", ExpectedStatusCode: 200},
+	}
+
+	var diags diag.Diagnostics
+	var data NvidiaCloudFunctionDataSourceModel
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, &NvidiaCloudFunctionResourceHealthModel{
+		Protocol:           types.StringValue("HTTP"),
+		Uri:                types.StringValue("/health"),
+		Port:               types.Int64Value(8000),
+		Timeout:            types.StringValue("PT10S"),
+		ExpectedStatusCode: types.Int64Value(200),
+	}, data.Health)
+
+	// Legacy functions only carrying a health_uri have no health block.
+	functionInfo.Health = nil
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Nil(t, data.Health)
+	assert.Equal(t, types.StringValue("/health"), data.HealthUri)
+}
+
+func TestUpdateNvidiaCloudFunctionDataSourceModel_ActiveInstances(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionDataSource{}
+	functionInfo := &utils.NvidiaCloudFunctionInfo{
+		ID:        "mock-function-id",
+		VersionID: "mock-version-id",
+		ActiveInstances: []utils.NvidiaCloudFunctionActiveInstance{
+			{
+				InstanceID:        "mock-instance-1",
+				InstanceStatus:    "ACTIVE",
+				InstanceType:      "gl40_1.br20_2xlarge",
+				Gpu:               "L40",
+				Backend:           "GFN",
+				Location:          "us-west-2",
+				InstanceCreatedAt: time.Date(2024, 3, 13, 9, 4, 20, 0, time.UTC),
+				InstanceUpdatedAt: time.Date(2024, 3, 13, 9, 10, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	var diags diag.Diagnostics
+	var data NvidiaCloudFunctionDataSourceModel
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	var instances []NvidiaCloudFunctionActiveInstanceModel
+	diags.Append(data.ActiveInstances.ElementsAs(ctx, &instances, false)...)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []NvidiaCloudFunctionActiveInstanceModel{
+		{
+			InstanceID:        types.StringValue("mock-instance-1"),
+			InstanceStatus:    types.StringValue("ACTIVE"),
+			InstanceType:      types.StringValue("gl40_1.br20_2xlarge"),
+			Gpu:               types.StringValue("L40"),
+			Backend:           types.StringValue("GFN"),
+			Location:          types.StringValue("us-west-2"),
+			InstanceCreatedAt: types.StringValue("2024-03-13T09:04:20Z"),
+			InstanceUpdatedAt: types.StringValue("2024-03-13T09:10:00Z"),
+		},
+	}, instances)
+
+	// Versions without a running instance expose a known, empty list.
+	functionInfo.ActiveInstances = nil
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.False(t, data.ActiveInstances.IsNull())
+	assert.Empty(t, data.ActiveInstances.Elements())
+}
+
+func TestUpdateNvidiaCloudFunctionDataSourceModel_DeploymentHealth(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionDataSource{}
+	functionInfo := &utils.NvidiaCloudFunctionInfo{ID: "mock-function-id", VersionID: "mock-version-id"}
+	functionDeployment := &utils.NvidiaCloudFunctionDeployment{
+		FunctionStatus: "FAILED",
+		HealthInfo: []interface{}{
+			map[string]interface{}{"gpu": "L40", "backend": "GFN", "instanceType": "gl40_1.br20_2xlarge", "error": "Failed to pull image", "sisRequestId": "mock-sis-request-id"},
+		},
+	}
+
+	var diags diag.Diagnostics
+	var data NvidiaCloudFunctionDataSourceModel
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, functionDeployment, nil)
+
+	var health []NvidiaCloudFunctionDeploymentHealthModel
+	diags.Append(data.DeploymentHealth.ElementsAs(ctx, &health, false)...)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []NvidiaCloudFunctionDeploymentHealthModel{
+		{
+			Gpu:          types.StringValue("L40"),
+			Backend:      types.StringValue("GFN"),
+			InstanceType: types.StringValue("gl40_1.br20_2xlarge"),
+			Error:        types.StringValue("Failed to pull image"),
+			SisRequestID: types.StringValue("mock-sis-request-id"),
+		},
+	}, health)
+
+	// Healthy deployments expose a known, empty list.
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, &utils.NvidiaCloudFunctionDeployment{FunctionStatus: "ACTIVE"}, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.False(t, data.DeploymentHealth.IsNull())
+	assert.Empty(t, data.DeploymentHealth.Elements())
+}
+
+func TestVersionAgeDays(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 3, 13, 9, 4, 20, 0, time.UTC)
+
+	assert.Equal(t, int64(0), versionAgeDays(createdAt, createdAt.Add(23*time.Hour)))
+	assert.Equal(t, int64(1), versionAgeDays(createdAt, createdAt.Add(24*time.Hour)))
+	assert.Equal(t, int64(30), versionAgeDays(createdAt, time.Date(2024, 4, 12, 10, 0, 0, 0, time.UTC)))
+	// Clock skew between NVCF and the client never yields a negative age.
+	assert.Equal(t, int64(0), versionAgeDays(createdAt, createdAt.Add(-time.Minute)))
+}
+
+func TestUpdateNvidiaCloudFunctionDataSourceModel_CreatedAt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionDataSource{}
+	functionInfo := &utils.NvidiaCloudFunctionInfo{
+		ID:        "mock-function-id",
+		VersionID: "mock-version-id",
+		CreatedAt: time.Date(2024, 3, 13, 9, 4, 20, 377756757, time.UTC),
+	}
+
+	var diags diag.Diagnostics
+	var data NvidiaCloudFunctionDataSourceModel
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.StringValue("2024-03-13T09:04:20Z"), data.CreatedAt)
+	assert.InDelta(t, versionAgeDays(functionInfo.CreatedAt, time.Now()), data.VersionAgeDays.ValueInt64(), 1)
+
+	functionInfo.CreatedAt = time.Time{}
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, functionInfo, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	assert.True(t, data.CreatedAt.IsNull())
+	assert.True(t, data.VersionAgeDays.IsNull())
+}
+
+func TestUpdateNvidiaCloudFunctionDataSourceModel_Status(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionDataSource{}
+
+	var diags diag.Diagnostics
+	var data NvidiaCloudFunctionDataSourceModel
+	d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{ID: "mock-function-id", VersionID: "mock-version-id", Status: "INACTIVE"}, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.StringValue("INACTIVE"), data.Status)
+	assert.Equal(t, types.BoolValue(false), data.IsActive)
+}
+
+func TestUpdateNvidiaCloudFunctionDataSourceModel_FunctionType(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionDataSource{}
+
+	tests := []struct {
+		name         string
+		functionType string
+		want         types.String
+	}{
+		{name: "Streaming", functionType: "STREAMING", want: types.StringValue("STREAMING")},
+		{name: "Default", functionType: "DEFAULT", want: types.StringValue("DEFAULT")},
+		{name: "Omitted", want: types.StringValue("DEFAULT")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			var data NvidiaCloudFunctionDataSourceModel
+			d.updateNvidiaCloudFunctionDataSourceModel(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{ID: "mock-function-id", VersionID: "mock-version-id", FunctionType: tt.functionType}, &utils.NvidiaCloudFunctionDeployment{}, nil)
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, tt.want, data.FunctionType)
+		})
+	}
+}
+
+func TestNvidiaCloudFunctionDataSourceRead_IncludeDeployment(t *testing.T) {
+	t.Parallel()
+
+	versionsPath := "GET /v2/orgs/mock-org/nvcf/functions/mock-function-id/versions"
+	deploymentPath := "GET /v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-id"
+	authorizationPath := "GET /v2/orgs/mock-org/nvcf/authorizations/functions/mock-function-id/versions/mock-version-id"
+
+	tests := []struct {
+		name              string
+		includeDeployment tftypes.Value
+		wantRequests      []string
+		wantIsActive      types.Bool
+	}{
+		{
+			name:              "Default",
+			includeDeployment: tftypes.NewValue(tftypes.Bool, nil),
+			wantRequests:      []string{versionsPath, deploymentPath, authorizationPath},
+			wantIsActive:      types.BoolValue(true),
+		},
+		{
+			name:              "MetadataOnly",
+			includeDeployment: tftypes.NewValue(tftypes.Bool, false),
+			wantRequests:      []string{versionsPath, authorizationPath},
+			wantIsActive:      types.BoolNull(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			rt := &routingRoundTripper{routes: map[string]*http.Response{
+				versionsPath: mockJsonResponse(http.StatusOK,
+					`{"functions":[{"id":"mock-function-id","versionId":"mock-version-id","name":"mock-function","status":"ACTIVE","containerImage":"nvcr.io/mock-org/mock-image:latest"}]}`),
+				deploymentPath:    mockJsonResponse(http.StatusOK, `{"deployment":{"deploymentId":"mock-deployment-id","functionStatus":"ACTIVE"}}`),
+				authorizationPath: mockJsonResponse(http.StatusOK, `{"function":{"id":"mock-function-id","versionId":"mock-version-id"}}`),
+			}}
+			d := &NvidiaCloudFunctionDataSource{
+				client: &utils.NVCFClient{
+					NgcEndpoint: "https://api.ngc.nvidia.com",
+					NgcApiKey:   "mock-api-key",
+					NgcOrg:      "mock-org",
+					HttpClient:  &http.Client{Transport: rt},
+				},
+			}
+
+			schemaResp := &datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+			assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+			schemaType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			raw := map[string]tftypes.Value{}
+			for name, attributeType := range schemaType.AttributeTypes {
+				raw[name] = tftypes.NewValue(attributeType, nil)
+			}
+			raw["function_id"] = tftypes.NewValue(tftypes.String, "mock-function-id")
+			raw["version_id"] = tftypes.NewValue(tftypes.String, "mock-version-id")
+			raw["include_deployment"] = tt.includeDeployment
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, raw)},
+			}
+			resp := &datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+			}
+
+			d.Read(ctx, req, resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var data NvidiaCloudFunctionDataSourceModel
+			assert.False(t, resp.State.Get(ctx, &data).HasError())
+			assert.Equal(t, "nvcr.io/mock-org/mock-image:latest", data.ContainerImage.ValueString())
+			assert.Equal(t, tt.wantIsActive, data.IsActive)
+			assert.Equal(t, tt.wantRequests, rt.requests)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type NvidiaCloudFunctionDeploymentResourceModel struct {
+	Id                       types.String   `tfsdk:"id"`
+	FunctionID               types.String   `tfsdk:"function_id"`
+	VersionID                types.String   `tfsdk:"version_id"`
+	DeploymentStatus         types.String   `tfsdk:"deployment_status"`
+	RequestQueueUrl          types.String   `tfsdk:"request_queue_url"`
+	RequestQueueRegion       types.String   `tfsdk:"request_queue_region"`
+	RequestQueueType         types.String   `tfsdk:"request_queue_type"`
+	DeploymentSpecifications types.Set      `tfsdk:"deployment_specifications"`
+	WaitForActive            types.Bool     `tfsdk:"wait_for_active"`
+	WaitForMinInstances      types.Bool     `tfsdk:"wait_for_min_instances"`
+	AutoInstanceType         types.Bool     `tfsdk:"auto_instance_type"`
+	GracefulDeletion         types.Bool     `tfsdk:"graceful_deletion"`
+	Timeouts                 timeouts.Value `tfsdk:"timeouts"`
+}
@@ -0,0 +1,495 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NvidiaCloudFunctionDeploymentResource{}
+var _ resource.ResourceWithImportState = &NvidiaCloudFunctionDeploymentResource{}
+var _ resource.ResourceWithValidateConfig = &NvidiaCloudFunctionDeploymentResource{}
+var _ resource.ResourceWithModifyPlan = &NvidiaCloudFunctionDeploymentResource{}
+
+func NewNvidiaCloudFunctionDeploymentResource() resource.Resource {
+	return &NvidiaCloudFunctionDeploymentResource{}
+}
+
+// NvidiaCloudFunctionDeploymentResource defines the resource implementation.
+type NvidiaCloudFunctionDeploymentResource struct {
+	client *utils.NVCFClient
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function_deployment"
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	deploymentSpecifications := deploymentSpecificationsSchema()
+	deploymentSpecifications.Optional = false
+	deploymentSpecifications.Required = true
+	deploymentSpecifications.MarkdownDescription = "Deployment specifications of the function version"
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deployment of an existing NVIDIA Cloud Function version, managed independently of the version itself. " +
+			"Don't set `deployment_specifications` on the `ngc_cloud_function` resource of the same version.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Read-only Deployment ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"function_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Function ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Function Version ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"deployment_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Deployment status, e.g. \"ACTIVE\", or \"DEPLOYING\" when `wait_for_active` is disabled",
+			},
+			"request_queue_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SQS queue URL receiving the async invocations of the function version",
+			},
+			"request_queue_region": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "AWS region of the request queue, e.g. \"us-west-2\"",
+			},
+			"request_queue_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Type of the request queue, \"FIFO\" or \"STANDARD\"",
+			},
+			"deployment_specifications": deploymentSpecifications,
+			"wait_for_active": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the deployment to become ACTIVE on create and update. When \"false\", the apply returns right after the deployment request is accepted. Default is \"true\"",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"wait_for_min_instances": schema.BoolAttribute{
+				MarkdownDescription: "Once the deployment is ACTIVE, also wait until as many instances as the sum of the `min_instances` of the deployment specifications are ACTIVE. " +
+					"NVCF reports the deployment ACTIVE as soon as a single instance is up. Requires `wait_for_active`. Default is \"false\"",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"auto_instance_type": schema.BoolAttribute{
+				MarkdownDescription: "Resolve the `instance_type` of the deployment specifications leaving it unset to the most cost-effective instance type of their `gpu_type`, " +
+					"i.e. the one with the fewest GPUs then CPU cores, among the cluster groups of their `backend` or `clusters`. Default is \"false\"",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"graceful_deletion": schema.BoolAttribute{
+				MarkdownDescription: "Let in-flight requests complete before the deployment is deleted. Default is \"false\"",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NvidiaCloudFunctionDeploymentResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The deployment specifications are validated the same way as the ones of the function resource.
+	functionData := NvidiaCloudFunctionResourceModel{DeploymentSpecifications: data.DeploymentSpecifications, AutoInstanceType: data.AutoInstanceType}
+	validateDeploymentTargets(ctx, functionData, &resp.Diagnostics)
+	validateInstanceTypes(ctx, functionData, &resp.Diagnostics)
+
+	if data.WaitForMinInstances.ValueBool() && !data.WaitForActive.IsUnknown() && !data.WaitForActive.IsNull() && !data.WaitForActive.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_for_min_instances"),
+			"Invalid Attribute Combination",
+			"The wait_for_min_instances attribute requires wait_for_active, "+
+				"the instances are only counted once the deployment is ACTIVE.",
+		)
+	}
+}
+
+// ModifyPlan summarizes a change in the number of deployment specifications, and plans the instance types
+// resolved by auto_instance_type on a prior apply.
+func (r *NvidiaCloudFunctionDeploymentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compare on create or destroy.
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	planDeploymentInstanceTypes(ctx, req, resp)
+
+	var plan, state NvidiaCloudFunctionDeploymentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addDeploymentSpecCountWarning(ctx, state.DeploymentSpecifications, plan.DeploymentSpecifications, &resp.Diagnostics)
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = ngcClient.NVCFClient()
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NvidiaCloudFunctionDeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	deploymentSpecifications := deploymentSpecificationsRequest(ctx, data.DeploymentSpecifications, &resp.Diagnostics)
+
+	if data.AutoInstanceType.ValueBool() && !resp.Diagnostics.HasError() {
+		resolveInstanceTypes(ctx, r.client, deploymentSpecifications, types.SetNull(deploymentSpecificationsSchema().NestedObject.Type()), &resp.Diagnostics)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createNvidiaCloudFunctionDeploymentResponse, err := r.client.CreateNvidiaCloudFunctionDeployment(
+		ctx, data.FunctionID.ValueString(), data.VersionID.ValueString(),
+		utils.CreateNvidiaCloudFunctionDeploymentRequest{
+			DeploymentSpecifications: deploymentSpecifications,
+		},
+	)
+
+	if err != nil {
+		addDeploymentError(&resp.Diagnostics, "Failed to create Cloud Function Deployment", err)
+		return
+	}
+
+	deployment := r.waitForDeployment(ctx, data, &createNvidiaCloudFunctionDeploymentResponse.Deployment, &resp.Diagnostics)
+
+	// The deployment exists even when it failed to become ACTIVE, it is saved so Terraform taints it.
+	r.updateDeploymentResourceModel(ctx, &data, deployment, &resp.Diagnostics)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NvidiaCloudFunctionDeploymentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read Cloud Function deployment",
+			err.Error(),
+		)
+		return
+	}
+
+	// NVCF answers with an empty deployment once the deployment, or its version, is deleted.
+	if readNvidiaCloudFunctionDeploymentResponse.Deployment.DeploymentID == "" {
+		tflog.Warn(ctx, fmt.Sprintf("Cloud Function deployment of version %s/%s no longer exists, removing from state", data.FunctionID.ValueString(), data.VersionID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.updateDeploymentResourceModel(ctx, &data, &readNvidiaCloudFunctionDeploymentResponse.Deployment, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state NvidiaCloudFunctionDeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Only the deployment specifications need an API call, the other attributes are local to Terraform.
+	if !plan.DeploymentSpecifications.Equal(state.DeploymentSpecifications) {
+		deploymentSpecifications := deploymentSpecificationsRequest(ctx, plan.DeploymentSpecifications, &resp.Diagnostics)
+
+		if plan.AutoInstanceType.ValueBool() && !resp.Diagnostics.HasError() {
+			resolveInstanceTypes(ctx, r.client, deploymentSpecifications, state.DeploymentSpecifications, &resp.Diagnostics)
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		currentDeployment, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, state.FunctionID.ValueString(), state.VersionID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read deployment for update", err.Error())
+			return
+		}
+		preserveDeploymentSpecExtras(deploymentSpecifications, currentDeployment.Deployment.DeploymentSpecifications)
+
+		updateNvidiaCloudFunctionDeploymentResponse, err := r.client.UpdateNvidiaCloudFunctionDeployment(
+			ctx, state.FunctionID.ValueString(), state.VersionID.ValueString(),
+			utils.UpdateNvidiaCloudFunctionDeploymentRequest{
+				DeploymentSpecifications: deploymentSpecifications,
+			},
+		)
+
+		if err != nil {
+			addDeploymentError(&resp.Diagnostics, "Failed to update Cloud Function Deployment", err)
+			return
+		}
+
+		deployment := r.waitForDeployment(ctx, plan, &updateNvidiaCloudFunctionDeploymentResponse.Deployment, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r.updateDeploymentResourceModel(ctx, &plan, deployment, &resp.Diagnostics)
+	} else {
+		plan.Id = state.Id
+		plan.DeploymentStatus = state.DeploymentStatus
+		plan.RequestQueueUrl = state.RequestQueueUrl
+		plan.RequestQueueRegion = state.RequestQueueRegion
+		plan.RequestQueueType = state.RequestQueueType
+		plan.DeploymentSpecifications = state.DeploymentSpecifications
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NvidiaCloudFunctionDeploymentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := r.client.DeleteNvidiaCloudFunctionDeployment(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString(), data.GracefulDeletion.ValueBool())
+
+	// The deployment is gone already when the version was deleted first.
+	if utils.IsNotFound(err) {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Failed to delete Cloud Function Deployment %s", data.VersionID.ValueString()),
+			err.Error(),
+		)
+		return
+	}
+
+	// The version may be deleted right after, e.g. when both are destroyed.
+	waitForDeploymentDeleted(ctx, r.client, data.FunctionID.ValueString(), data.VersionID.ValueString(), &resp.Diagnostics)
+}
+
+func (r *NvidiaCloudFunctionDeploymentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	functionID, versionID, err := parseFunctionImportID(req.ID)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("function_id"), functionID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version_id"), versionID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_active"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_min_instances"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("graceful_deletion"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("auto_instance_type"), false)...)
+}
+
+// waitForDeployment waits for the requested deployment to become ACTIVE, unless disabled, and returns its latest state.
+func (r *NvidiaCloudFunctionDeploymentResource) waitForDeployment(
+	ctx context.Context,
+	data NvidiaCloudFunctionDeploymentResourceModel,
+	deployment *utils.NvidiaCloudFunctionDeployment,
+	diag *diag.Diagnostics,
+) *utils.NvidiaCloudFunctionDeployment {
+	if !data.WaitForActive.ValueBool() {
+		tflog.Info(ctx, "skip waiting for the function deployment to become active")
+		return deployment
+	}
+
+	err := r.client.WaitingDeploymentCompleted(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+	if err != nil {
+		diag.AddError(
+			"Cloud Function Deployment did not become ACTIVE",
+			err.Error(),
+		)
+		return deployment
+	}
+
+	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString())
+	if err != nil {
+		diag.AddError("Failed to read Cloud Function deployment", err.Error())
+		return deployment
+	}
+
+	if data.WaitForMinInstances.ValueBool() {
+		err = r.client.WaitingMinInstancesActive(ctx, data.FunctionID.ValueString(), data.VersionID.ValueString(), readNvidiaCloudFunctionDeploymentResponse.Deployment.DeploymentSpecifications)
+		if err != nil {
+			diag.AddError(
+				"Cloud Function Deployment did not reach its minimum instances",
+				err.Error(),
+			)
+		}
+	}
+	return &readNvidiaCloudFunctionDeploymentResponse.Deployment
+}
+
+// updateDeploymentResourceModel updates the Terraform model with data from the API response.
+// NVCF deployments carry no description or tags, these stay on the function version.
+func (r *NvidiaCloudFunctionDeploymentResource) updateDeploymentResourceModel(
+	ctx context.Context,
+	data *NvidiaCloudFunctionDeploymentResourceModel,
+	deployment *utils.NvidiaCloudFunctionDeployment,
+	diag *diag.Diagnostics,
+) {
+	data.Id = types.StringValue(deployment.DeploymentID)
+
+	if deployment.FunctionStatus != "" {
+		data.DeploymentStatus = types.StringValue(deployment.FunctionStatus)
+	} else {
+		data.DeploymentStatus = types.StringNull()
+	}
+
+	if deployment.RequestQueueURL != "" {
+		data.RequestQueueUrl = types.StringValue(deployment.RequestQueueURL)
+		data.RequestQueueRegion = types.StringValue(deployment.RequestQueueRegion())
+		data.RequestQueueType = types.StringValue(deployment.RequestQueueType())
+	} else {
+		data.RequestQueueUrl = types.StringNull()
+		data.RequestQueueRegion = types.StringNull()
+		data.RequestQueueType = types.StringNull()
+	}
+
+	if data.WaitForActive.IsNull() || data.WaitForActive.IsUnknown() {
+		data.WaitForActive = types.BoolValue(true)
+	}
+
+	if data.WaitForMinInstances.IsNull() || data.WaitForMinInstances.IsUnknown() {
+		data.WaitForMinInstances = types.BoolValue(false)
+	}
+
+	if data.GracefulDeletion.IsNull() || data.GracefulDeletion.IsUnknown() {
+		data.GracefulDeletion = types.BoolValue(false)
+	}
+
+	if data.AutoInstanceType.IsNull() || data.AutoInstanceType.IsUnknown() {
+		data.AutoInstanceType = types.BoolValue(false)
+	}
+
+	if deployment.DeploymentSpecifications != nil {
+		data.DeploymentSpecifications = deploymentSpecificationsSet(ctx, deployment.DeploymentSpecifications, diag)
+	}
+}
@@ -0,0 +1,106 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build !unittest
+// +build !unittest
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/testutils"
+)
+
+func generateDeploymentResourceConfig(resourceName string, functionID string, versionID string, maxInstances int) string {
+	return fmt.Sprintf(`
+		resource "ngc_cloud_function_deployment" "%s" {
+			function_id = "%s"
+			version_id  = "%s"
+			deployment_specifications = [
+				{
+					clusters                = ["%s"]
+					instance_type           = "%s"
+					gpu_type                = "%s"
+					max_instances           = %d
+					min_instances           = 1
+					max_request_concurrency = 1
+				}
+			]
+		}
+	`, resourceName, functionID, versionID, testutils.TestClusters[0], testutils.TestInstanceType, testutils.TestGpuType, maxInstances)
+}
+
+func generateDeploymentStateResourceId(resourceName string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		var rawState map[string]string
+		for _, m := range state.Modules {
+			if len(m.Resources) > 0 {
+				if v, ok := m.Resources[resourceName]; ok {
+					rawState = v.Primary.Attributes
+				}
+			}
+		}
+		return fmt.Sprintf("%s,%s", rawState["function_id"], rawState["version_id"]), nil
+	}
+}
+
+func TestAccCloudFunctionDeploymentResource_CreateAndUpdateAndDeleteDeploymentSuccess(t *testing.T) {
+	var resourceName = testutils.TestCommonPrefix + "deployment-resource"
+	var testCloudFunctionDeploymentResourceFullPath = fmt.Sprintf("ngc_cloud_function_deployment.%s", resourceName)
+
+	functionInfo := testutils.CreateContainerFunction(t)
+	defer testutils.DeleteFunction(t, functionInfo.Function.ID, functionInfo.Function.VersionID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Verify Deployment Creation
+			{
+				Config: generateDeploymentResourceConfig(resourceName, functionInfo.Function.ID, functionInfo.Function.VersionID, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(testCloudFunctionDeploymentResourceFullPath, "id"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "function_id", functionInfo.Function.ID),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "version_id", functionInfo.Function.VersionID),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_status", "ACTIVE"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.#", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.gpu_type", testutils.TestGpuType),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.instance_type", testutils.TestInstanceType),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.max_instances", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.min_instances", "1"),
+				),
+			},
+			// Verify Deployment Update
+			{
+				Config: generateDeploymentResourceConfig(resourceName, functionInfo.Function.ID, functionInfo.Function.VersionID, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_status", "ACTIVE"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.#", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.max_instances", "2"),
+					resource.TestCheckResourceAttr(testCloudFunctionDeploymentResourceFullPath, "deployment_specifications.0.min_instances", "1"),
+				),
+			},
+			// Verify Deployment Import
+			{
+				ResourceName:      testCloudFunctionDeploymentResourceFullPath,
+				ImportStateIdFunc: generateDeploymentStateResourceId(testCloudFunctionDeploymentResourceFullPath),
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"timeouts",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,68 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestUpdateDeploymentResourceModel_Metadata(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionDeploymentResource{}
+
+	var response utils.ReadNvidiaCloudFunctionDeploymentResponse
+	assert.NoError(t, json.Unmarshal([]byte(`{"deployment": {
+		"deploymentId": "mock-deployment-id",
+		"functionId": "mock-function-id",
+		"functionVersionId": "mock-version-id",
+		"functionStatus": "ACTIVE",
+		"requestQueueUrl": "https://sqs.us-west-2.amazonaws.com/052277528122/mock-queue.fifo",
+		"deploymentSpecifications": [{"gpu": "L40", "instanceType": "gl40_1.br20_2xlarge", "minInstances": 1, "maxInstances": 2, "maxRequestConcurrency": 1}]
+	}}`), &response))
+
+	data := NvidiaCloudFunctionDeploymentResourceModel{
+		FunctionID: types.StringValue("mock-function-id"),
+		VersionID:  types.StringValue("mock-version-id"),
+	}
+
+	var diags diag.Diagnostics
+	r.updateDeploymentResourceModel(ctx, &data, &response.Deployment, &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.StringValue("mock-deployment-id"), data.Id)
+	assert.Equal(t, types.StringValue("ACTIVE"), data.DeploymentStatus)
+	assert.Equal(t, types.StringValue("https://sqs.us-west-2.amazonaws.com/052277528122/mock-queue.fifo"), data.RequestQueueUrl)
+	assert.Equal(t, types.StringValue("us-west-2"), data.RequestQueueRegion)
+	assert.Equal(t, types.StringValue("FIFO"), data.RequestQueueType)
+	assert.Len(t, data.DeploymentSpecifications.Elements(), 1)
+	assert.Equal(t, types.BoolValue(true), data.WaitForActive)
+	assert.Equal(t, types.BoolValue(false), data.WaitForMinInstances)
+
+	// A deployment without request queue, e.g. a deleted one, clears the queue attributes.
+	r.updateDeploymentResourceModel(ctx, &data, &utils.NvidiaCloudFunctionDeployment{DeploymentID: "mock-deployment-id"}, &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, data.RequestQueueUrl.IsNull())
+	assert.True(t, data.RequestQueueRegion.IsNull())
+	assert.True(t, data.RequestQueueType.IsNull())
+}
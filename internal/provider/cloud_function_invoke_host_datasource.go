@@ -0,0 +1,113 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NvidiaCloudFunctionInvokeHostDataSource{}
+
+func NewNvidiaCloudFunctionInvokeHostDataSource() datasource.DataSource {
+	return &NvidiaCloudFunctionInvokeHostDataSource{}
+}
+
+// NvidiaCloudFunctionInvokeHostDataSource defines the data source implementation.
+type NvidiaCloudFunctionInvokeHostDataSource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionInvokeHostDataSourceModel describes the data source data model.
+type NvidiaCloudFunctionInvokeHostDataSourceModel struct {
+	FunctionID types.String `tfsdk:"function_id"`
+	VersionID  types.String `tfsdk:"version_id"`
+	InvokeHost types.String `tfsdk:"invoke_host"`
+	InvokeUrl  types.String `tfsdk:"invoke_url"`
+}
+
+func (d *NvidiaCloudFunctionInvokeHostDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function_invoke_host"
+}
+
+func (d *NvidiaCloudFunctionInvokeHostDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves the NVCF invocation host matching the configured NGC endpoint",
+		Attributes: map[string]schema.Attribute{
+			"function_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Function ID used to build `invoke_url`",
+			},
+			"version_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Function Version ID used to build `invoke_url`. Requests are routed to any active version when unset.",
+			},
+			"invoke_host": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "NVCF invocation host, e.g. \"https://api.nvcf.nvidia.com\"",
+			},
+			"invoke_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Invocation URL of the function. Empty when `function_id` is not set.",
+			},
+		},
+	}
+}
+
+func (d *NvidiaCloudFunctionInvokeHostDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = ngcClient.NVCFClient()
+}
+
+func (d *NvidiaCloudFunctionInvokeHostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NvidiaCloudFunctionInvokeHostDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invokeHost := d.client.NvcfInvokeHost(ctx)
+	data.InvokeHost = types.StringValue(invokeHost)
+
+	if data.FunctionID.ValueString() == "" {
+		data.InvokeUrl = types.StringValue("")
+	} else if data.VersionID.ValueString() == "" {
+		data.InvokeUrl = types.StringValue(fmt.Sprintf("%s/v2/nvcf/pexec/functions/%s", invokeHost, data.FunctionID.ValueString()))
+	} else {
+		data.InvokeUrl = types.StringValue(fmt.Sprintf("%s/v2/nvcf/pexec/functions/%s/versions/%s", invokeHost, data.FunctionID.ValueString(), data.VersionID.ValueString()))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
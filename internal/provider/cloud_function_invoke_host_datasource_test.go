@@ -0,0 +1,50 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build !unittest
+// +build !unittest
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/testutils"
+)
+
+func TestAccCloudFunctionInvokeHostDataSource_Success(t *testing.T) {
+	var datasourceName = testutils.TestCommonPrefix + "invoke-host-datasource"
+	var datasourceFullPath = fmt.Sprintf("data.ngc_cloud_function_invoke_host.%s", datasourceName)
+	var functionID = "033c9664-f5b0-4bd2-8918-5aab085fc8db"
+	var versionID = "f0cc4c95-108c-471a-b52c-a2bd5c0024c2"
+
+	invokeHost := testutils.TestNVCFClient.NvcfInvokeHost(testutils.Ctx)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "ngc_cloud_function_invoke_host" "%s" {
+						function_id = "%s"
+						version_id  = "%s"
+					}
+				`, datasourceName, functionID, versionID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceFullPath, "invoke_host", invokeHost),
+					resource.TestCheckResourceAttr(datasourceFullPath, "invoke_url", fmt.Sprintf("%s/v2/nvcf/pexec/functions/%s/versions/%s", invokeHost, functionID, versionID)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,71 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestNvidiaCloudFunctionInvokeHostDataSourceRead_InvokeEndpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := &NvidiaCloudFunctionInvokeHostDataSource{
+		client: &utils.NVCFClient{
+			NgcEndpoint:    "https://api.stg.ngc.nvidia.com",
+			NgcOrg:         "mock-org",
+			InvokeEndpoint: "https://mock-invoke.nvidia.com/",
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"function_id": tftypes.NewValue(tftypes.String, "mock-function-id"),
+				"version_id":  tftypes.NewValue(tftypes.String, "mock-version-id"),
+				"invoke_host": tftypes.NewValue(tftypes.String, nil),
+				"invoke_url":  tftypes.NewValue(tftypes.String, nil),
+			}),
+		},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaType, nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data NvidiaCloudFunctionInvokeHostDataSourceModel
+	assert.False(t, resp.State.Get(ctx, &data).HasError())
+
+	// The pinned endpoint takes precedence over the one derived from the NGC endpoint.
+	assert.Equal(t, "https://mock-invoke.nvidia.com", data.InvokeHost.ValueString())
+	assert.Equal(t, "https://mock-invoke.nvidia.com/v2/nvcf/pexec/functions/mock-function-id/versions/mock-version-id", data.InvokeUrl.ValueString())
+}
@@ -73,6 +73,48 @@ type NvidiaCloudFunctionResourceDeploymentSpecificationModel struct {
 	Regions               types.Set    `tfsdk:"regions"`
 }
 
+type NvidiaCloudFunctionActiveInstanceModel struct {
+	InstanceID        types.String `tfsdk:"instance_id"`
+	InstanceStatus    types.String `tfsdk:"instance_status"`
+	InstanceType      types.String `tfsdk:"instance_type"`
+	Gpu               types.String `tfsdk:"gpu"`
+	Backend           types.String `tfsdk:"backend"`
+	Location          types.String `tfsdk:"location"`
+	InstanceCreatedAt types.String `tfsdk:"instance_created_at"`
+	InstanceUpdatedAt types.String `tfsdk:"instance_updated_at"`
+}
+
+func (m *NvidiaCloudFunctionActiveInstanceModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"instance_id":         types.StringType,
+		"instance_status":     types.StringType,
+		"instance_type":       types.StringType,
+		"gpu":                 types.StringType,
+		"backend":             types.StringType,
+		"location":            types.StringType,
+		"instance_created_at": types.StringType,
+		"instance_updated_at": types.StringType,
+	}
+}
+
+type NvidiaCloudFunctionDeploymentHealthModel struct {
+	Gpu          types.String `tfsdk:"gpu"`
+	Backend      types.String `tfsdk:"backend"`
+	InstanceType types.String `tfsdk:"instance_type"`
+	Error        types.String `tfsdk:"error"`
+	SisRequestID types.String `tfsdk:"sis_request_id"`
+}
+
+func (m *NvidiaCloudFunctionDeploymentHealthModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"gpu":            types.StringType,
+		"backend":        types.StringType,
+		"instance_type":  types.StringType,
+		"error":          types.StringType,
+		"sis_request_id": types.StringType,
+	}
+}
+
 type NvidiaCloudFunctionTelemetryModel struct {
 	LogsTelemetryId    types.String `tfsdk:"logs_telemetry_id"`
 	MetricsTelemetryId types.String `tfsdk:"metrics_telemetry_id"`
@@ -88,32 +130,47 @@ func (m *NvidiaCloudFunctionTelemetryModel) attrTypes() map[string]attr.Type {
 }
 
 type NvidiaCloudFunctionResourceModel struct {
-	Id                       types.String   `tfsdk:"id"`
-	FunctionID               types.String   `tfsdk:"function_id"`
-	VersionID                types.String   `tfsdk:"version_id"`
-	NcaId                    types.String   `tfsdk:"nca_id"`
-	DeploymentID             types.String   `tfsdk:"deployment_id"`
-	FunctionName             types.String   `tfsdk:"function_name"`
-	InferencePort            types.Int64    `tfsdk:"inference_port"`
-	HelmChart                types.String   `tfsdk:"helm_chart"`
-	HelmChartServiceName     types.String   `tfsdk:"helm_chart_service_name"`
-	ContainerImage           types.String   `tfsdk:"container_image"`
-	ContainerArgs            types.String   `tfsdk:"container_args"`
-	ContainerEnvironment     types.Set      `tfsdk:"container_environment"`
-	InferenceUrl             types.String   `tfsdk:"inference_url"`
-	HealthUri                types.String   `tfsdk:"health_uri"` // Deprecated
-	Health                   types.Object   `tfsdk:"health"`
-	APIBodyFormat            types.String   `tfsdk:"api_body_format"`
-	DeploymentSpecifications types.Set      `tfsdk:"deployment_specifications"`
-	Tags                     types.Set      `tfsdk:"tags"`
-	Description              types.String   `tfsdk:"description"`
-	Models                   types.Set      `tfsdk:"models"`
-	Resources                types.Set      `tfsdk:"resources"`
-	FunctionType             types.String   `tfsdk:"function_type"`
-	KeepFailedResource       types.Bool     `tfsdk:"keep_failed_resource"`
-	Timeouts                 timeouts.Value `tfsdk:"timeouts"`
-	Secrets                  types.Set      `tfsdk:"secrets"`
-	AuthorizedParties        types.Set      `tfsdk:"authorized_parties"`
-	Telemetries              types.Object   `tfsdk:"telemetries"`
-	GracefulDeletion         types.Bool     `tfsdk:"graceful_deletion"`
+	Id                         types.String   `tfsdk:"id"`
+	FunctionID                 types.String   `tfsdk:"function_id"`
+	VersionID                  types.String   `tfsdk:"version_id"`
+	NcaId                      types.String   `tfsdk:"nca_id"`
+	DeploymentID               types.String   `tfsdk:"deployment_id"`
+	DeploymentStatus           types.String   `tfsdk:"deployment_status"`
+	FunctionName               types.String   `tfsdk:"function_name"`
+	InferencePort              types.Int64    `tfsdk:"inference_port"`
+	HelmChart                  types.String   `tfsdk:"helm_chart"`
+	HelmChartServiceName       types.String   `tfsdk:"helm_chart_service_name"`
+	ContainerImage             types.String   `tfsdk:"container_image"`
+	ContainerImageTagCheck     types.String   `tfsdk:"container_image_tag_check"`
+	ContainerArgs              types.String   `tfsdk:"container_args"`
+	ContainerEnvironment       types.Set      `tfsdk:"container_environment"`
+	InferenceUrl               types.String   `tfsdk:"inference_url"`
+	HealthUri                  types.String   `tfsdk:"health_uri"` // Deprecated
+	Health                     types.Object   `tfsdk:"health"`
+	APIBodyFormat              types.String   `tfsdk:"api_body_format"`
+	DeploymentSpecifications   types.Set      `tfsdk:"deployment_specifications"`
+	ConfigurationSchema        types.String   `tfsdk:"configuration_schema"`
+	Tags                       types.Set      `tfsdk:"tags"`
+	VersionLabel               types.String   `tfsdk:"version_label"`
+	Description                types.String   `tfsdk:"description"`
+	Models                     types.Set      `tfsdk:"models"`
+	Resources                  types.Set      `tfsdk:"resources"`
+	ModelsCount                types.Int64    `tfsdk:"models_count"`
+	ResourcesCount             types.Int64    `tfsdk:"resources_count"`
+	FunctionType               types.String   `tfsdk:"function_type"`
+	KeepFailedResource         types.Bool     `tfsdk:"keep_failed_resource"`
+	WarnWithoutDeployment      types.Bool     `tfsdk:"warn_without_deployment"`
+	WaitForActive              types.Bool     `tfsdk:"wait_for_active"`
+	MaxDeploymentWait          types.String   `tfsdk:"max_deployment_wait"`
+	CreateRetry                types.Bool     `tfsdk:"create_retry"`
+	AutoInstanceType           types.Bool     `tfsdk:"auto_instance_type"`
+	Timeouts                   timeouts.Value `tfsdk:"timeouts"`
+	Secrets                    types.Set      `tfsdk:"secrets"`
+	AuthorizedParties          types.Set      `tfsdk:"authorized_parties"`
+	Telemetries                types.Object   `tfsdk:"telemetries"`
+	GracefulDeletion           types.Bool     `tfsdk:"graceful_deletion"`
+	DeleteAllVersionsOnDestroy types.Bool     `tfsdk:"delete_all_versions_on_destroy"`
+	ForceDestroy               types.Bool     `tfsdk:"force_destroy"`
+	LastRecreateReason         types.List     `tfsdk:"last_recreate_reason"`
+	ForceNewVersion            types.String   `tfsdk:"force_new_version"`
 }
@@ -13,12 +13,17 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -31,6 +36,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -39,10 +45,22 @@ import (
 )
 
 const DEFAULT_TIMEOUT_SEC = 60 * 60
+const FAILED_VERSION_CLEANUP_TIMEOUT_SEC = 5 * 60
+
+// NVCF has no version metadata besides tags, so the version label is stored as a tag with this prefix.
+const VERSION_LABEL_TAG_PREFIX = "version-label:"
+
+// Tag recording the provider version which created or last updated the function, see tag_provider_version.
+const PROVIDER_VERSION_TAG_PREFIX = "managed_by_provider_version:"
+
+// Private state key carrying last_recreate_reason from the replace plan to the plan of the replacement.
+const LAST_RECREATE_REASON_PRIVATE_KEY = "last_recreate_reason"
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NvidiaCloudFunctionResource{}
 var _ resource.ResourceWithImportState = &NvidiaCloudFunctionResource{}
+var _ resource.ResourceWithValidateConfig = &NvidiaCloudFunctionResource{}
+var _ resource.ResourceWithModifyPlan = &NvidiaCloudFunctionResource{}
 
 func NewNvidiaCloudFunctionResource() resource.Resource {
 	return &NvidiaCloudFunctionResource{}
@@ -50,7 +68,24 @@ func NewNvidiaCloudFunctionResource() resource.Resource {
 
 // NvidiaCloudFunctionResource defines the resource implementation.
 type NvidiaCloudFunctionResource struct {
-	client *utils.NVCFClient
+	client                   *utils.NVCFClient
+	providerVersion          string
+	deploymentDriftDetection bool
+	// artifactHost is prepended to the model and resource URIs without a host.
+	artifactHost string
+}
+
+// functionNcaID returns the NCA ID of the account owning the function. The function version is the source of truth,
+// the deployment, which carries the same ID, only fills in when the version comes without it.
+func functionNcaID(functionInfo *utils.NvidiaCloudFunctionInfo, functionDeployment *utils.NvidiaCloudFunctionDeployment) string {
+	if functionInfo.NcaID != "" {
+		return functionInfo.NcaID
+	}
+
+	if functionDeployment != nil {
+		return functionDeployment.NcaID
+	}
+	return ""
 }
 
 //gocyclo:ignore
@@ -69,6 +104,38 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 		data.KeepFailedResource = types.BoolValue(false)
 	}
 
+	if data.ContainerImageTagCheck.IsNull() || data.ContainerImageTagCheck.IsUnknown() {
+		data.ContainerImageTagCheck = types.StringValue("WARN")
+	}
+
+	if data.WaitForActive.IsNull() || data.WaitForActive.IsUnknown() {
+		data.WaitForActive = types.BoolValue(true)
+	}
+
+	if data.WarnWithoutDeployment.IsNull() || data.WarnWithoutDeployment.IsUnknown() {
+		data.WarnWithoutDeployment = types.BoolValue(false)
+	}
+
+	if data.CreateRetry.IsNull() || data.CreateRetry.IsUnknown() {
+		data.CreateRetry = types.BoolValue(false)
+	}
+
+	if data.AutoInstanceType.IsNull() || data.AutoInstanceType.IsUnknown() {
+		data.AutoInstanceType = types.BoolValue(false)
+	}
+
+	if data.DeleteAllVersionsOnDestroy.IsNull() || data.DeleteAllVersionsOnDestroy.IsUnknown() {
+		data.DeleteAllVersionsOnDestroy = types.BoolValue(false)
+	}
+
+	if data.ForceDestroy.IsNull() || data.ForceDestroy.IsUnknown() {
+		data.ForceDestroy = types.BoolValue(false)
+	}
+
+	if data.LastRecreateReason.IsUnknown() {
+		data.LastRecreateReason = types.ListNull(types.StringType)
+	}
+
 	if functionInfo.APIBodyFormat != "" {
 		data.APIBodyFormat = types.StringValue(functionInfo.APIBodyFormat)
 	}
@@ -77,15 +144,24 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 		data.InferenceUrl = types.StringValue(functionInfo.InferenceURL)
 	}
 
-	if functionInfo.NcaID != "" {
-		data.NcaId = types.StringValue(functionInfo.NcaID)
+	// A response without NCA ID keeps the known one.
+	if ncaID := functionNcaID(functionInfo, functionDeployment); ncaID != "" {
+		data.NcaId = types.StringValue(ncaID)
+	} else if data.NcaId.IsUnknown() {
+		data.NcaId = types.StringNull()
 	}
 
 	if functionInfo.Name != "" {
 		data.FunctionName = types.StringValue(functionInfo.Name)
 	}
 
-	if functionInfo.HealthURI != "" {
+	// NVCF derives health_uri and the health block from each other, the health block takes precedence so
+	// the echoed health_uri never flips the known value back and forth.
+	if functionInfo.Health != nil {
+		if data.HealthUri.IsNull() || data.HealthUri.IsUnknown() {
+			data.HealthUri = types.StringValue(functionInfo.Health.URI)
+		}
+	} else if functionInfo.HealthURI != "" {
 		data.HealthUri = types.StringValue(functionInfo.HealthURI)
 	}
 
@@ -119,54 +195,35 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 		data.DeploymentID = types.StringValue("")
 	}
 
-	if functionDeployment != nil && functionDeployment.DeploymentSpecifications != nil {
-		deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0)
-		for _, v := range functionDeployment.DeploymentSpecifications {
-			deploymentSpecification := NvidiaCloudFunctionResourceDeploymentSpecificationModel{
-				GpuSpecificationID:    types.StringValue(v.GpuSpecificationID),
-				InstanceType:          types.StringValue(v.InstanceType),
-				GpuType:               types.StringValue(v.Gpu),
-				MaxInstances:          types.Int64Value(int64(v.MaxInstances)),
-				MinInstances:          types.Int64Value(int64(v.MinInstances)),
-				MaxRequestConcurrency: types.Int64Value(int64(v.MaxRequestConcurrency)),
-			}
-
-			if v.Backend != "" {
-				deploymentSpecification.Backend = types.StringValue(v.Backend)
-			}
-
-			if v.Clusters != nil {
-				clusters, clustersSetFromDiag := types.SetValueFrom(ctx, types.StringType, v.Clusters)
-				diag.Append(clustersSetFromDiag...)
-				deploymentSpecification.Clusters = clusters
-			} else {
-				deploymentSpecification.Clusters = types.SetNull(types.StringType)
-			}
+	if functionDeployment != nil && functionDeployment.FunctionStatus != "" {
+		data.DeploymentStatus = types.StringValue(functionDeployment.FunctionStatus)
+	} else {
+		data.DeploymentStatus = types.StringNull()
+	}
 
-			if v.Regions != nil {
-				regions, regionsSetFromDiag := types.SetValueFrom(ctx, types.StringType, v.Regions)
-				diag.Append(regionsSetFromDiag...)
-				deploymentSpecification.Regions = regions
-			} else {
-				deploymentSpecification.Regions = types.SetNull(types.StringType)
-			}
+	if functionDeployment != nil && functionDeployment.DeploymentSpecifications != nil {
+		data.DeploymentSpecifications = deploymentSpecificationsSet(ctx, functionDeployment.DeploymentSpecifications, diag)
+	}
 
-			if v.Configuration != nil {
-				configuration, _ := json.Marshal(v.Configuration)
-				deploymentSpecification.Configuration = types.StringValue(string(configuration))
-			}
+	if functionInfo.Tags != nil {
+		versionLabel, tags := splitVersionLabelTag(functionInfo.Tags)
+		tags = withoutTagPrefix(tags, PROVIDER_VERSION_TAG_PREFIX)
+		if versionLabel != "" {
+			data.VersionLabel = types.StringValue(versionLabel)
+		} else {
+			data.VersionLabel = types.StringNull()
+		}
 
-			deploymentSpecifications = append(deploymentSpecifications, deploymentSpecification)
+		// NVCF may attach its own tags to a function, only the ones managed by this resource are tracked.
+		if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
+			priorTags := make([]string, 0, len(data.Tags.Elements()))
+			diag.Append(data.Tags.ElementsAs(ctx, &priorTags, false)...)
+			tags = managedTags(tags, priorTags)
 		}
-		deploymentSpecificationsSetType, deploymentSpecificationsSetTypeDiag := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), deploymentSpecifications)
-		diag.Append(deploymentSpecificationsSetTypeDiag...)
-		data.DeploymentSpecifications = deploymentSpecificationsSetType
-	}
 
-	if functionInfo.Tags != nil {
-		tags, tagsSetFromDiag := types.SetValueFrom(ctx, types.StringType, functionInfo.Tags)
+		tagsSetType, tagsSetFromDiag := types.SetValueFrom(ctx, types.StringType, tags)
 		diag.Append(tagsSetFromDiag...)
-		data.Tags = tags
+		data.Tags = tagsSetType
 	}
 
 	if functionInfo.Health != nil {
@@ -203,11 +260,12 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 	}
 
 	if functionInfo.Resources != nil {
+		priorUris := priorArtifactUris(ctx, data.Resources)
 		resources := make([]NvidiaCloudFunctionResourceResourceModel, 0)
 		for _, v := range functionInfo.Resources {
 			resource := NvidiaCloudFunctionResourceResourceModel{
 				Name:    types.StringValue(v.Name),
-				Uri:     types.StringValue(v.URI),
+				Uri:     types.StringValue(artifactUri(priorUris, v.Name, v.URI)),
 				Version: types.StringValue(v.Version),
 			}
 			resources = append(resources, resource)
@@ -218,11 +276,12 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 	}
 
 	if functionInfo.Models != nil {
+		priorUris := priorArtifactUris(ctx, data.Models)
 		models := make([]NvidiaCloudFunctionResourceModelModel, 0)
 		for _, v := range functionInfo.Models {
 			model := NvidiaCloudFunctionResourceModelModel{
 				Name:    types.StringValue(v.Name),
-				Uri:     types.StringValue(v.URI),
+				Uri:     types.StringValue(artifactUri(priorUris, v.Name, v.URI)),
 				Version: types.StringValue(v.Version),
 			}
 			models = append(models, model)
@@ -232,6 +291,9 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 		data.Models = modelsSetType
 	}
 
+	data.ModelsCount = types.Int64Value(int64(len(functionInfo.Models)))
+	data.ResourcesCount = types.Int64Value(int64(len(functionInfo.Resources)))
+
 	authorizeParties := make([]NvidiaCloudFunctionResourceAuthorizedPartyModel, 0)
 
 	if authorizedAccounts != nil && authorizedAccounts.Function.AuthorizedParties != nil {
@@ -264,7 +326,153 @@ func (r *NvidiaCloudFunctionResource) updateNvidiaCloudFunctionResourceModelBase
 		data.Telemetries = telemetriesObjectType
 	}
 
-	// We don't update Secret from response, since the secret won't return in response.
+	// We don't update Secret from response, since the secret won't return in response. Only their names are
+	// returned, the values are kept from the configuration and a secret removed outside of Terraform is dropped,
+	// all of them when no name is returned.
+	if !data.Secrets.IsNull() && !data.Secrets.IsUnknown() {
+		data.Secrets = preservedSecrets(ctx, data.Secrets, functionInfo.Secrets, diag)
+	}
+}
+
+// preservedSecrets keeps the known secrets whose name NVCF still returns, along with their write-only value.
+func preservedSecrets(ctx context.Context, secrets types.Set, names []string, diag *diag.Diagnostics) types.Set {
+	known := make([]NvidiaCloudFunctionResourceSecretModel, 0, len(secrets.Elements()))
+	diag.Append(secrets.ElementsAs(ctx, &known, false)...)
+
+	if diag.HasError() {
+		return secrets
+	}
+
+	preserved := make([]NvidiaCloudFunctionResourceSecretModel, 0, len(known))
+	for _, v := range known {
+		if v.Name.IsUnknown() || slices.Contains(names, v.Name.ValueString()) {
+			preserved = append(preserved, v)
+		}
+	}
+
+	if len(preserved) == len(known) {
+		return secrets
+	}
+
+	preservedSet, d := types.SetValueFrom(ctx, secrets.ElementType(ctx), preserved)
+	diag.Append(d...)
+	return preservedSet
+}
+
+// deploymentSpecificationsSet converts the deployment specifications of an API response to their Terraform set.
+func deploymentSpecificationsSet(ctx context.Context, specs []utils.NvidiaCloudFunctionDeploymentSpecification, diag *diag.Diagnostics) types.Set {
+	deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0)
+	for _, v := range specs {
+		deploymentSpecification := NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+			GpuSpecificationID:    types.StringValue(v.GpuSpecificationID),
+			InstanceType:          types.StringValue(v.InstanceType),
+			GpuType:               types.StringValue(v.Gpu),
+			MaxInstances:          types.Int64Value(int64(v.MaxInstances)),
+			MinInstances:          types.Int64Value(int64(v.MinInstances)),
+			MaxRequestConcurrency: types.Int64Value(int64(v.MaxRequestConcurrency)),
+		}
+
+		if v.Backend != "" {
+			deploymentSpecification.Backend = types.StringValue(v.Backend)
+		}
+
+		if v.Clusters != nil {
+			clusters, clustersSetFromDiag := types.SetValueFrom(ctx, types.StringType, v.Clusters)
+			diag.Append(clustersSetFromDiag...)
+			deploymentSpecification.Clusters = clusters
+		} else {
+			deploymentSpecification.Clusters = types.SetNull(types.StringType)
+		}
+
+		if v.Regions != nil {
+			regions, regionsSetFromDiag := types.SetValueFrom(ctx, types.StringType, v.Regions)
+			diag.Append(regionsSetFromDiag...)
+			deploymentSpecification.Regions = regions
+		} else {
+			deploymentSpecification.Regions = types.SetNull(types.StringType)
+		}
+
+		// Container functions have no configuration, keep it explicitly null so reads stay stable.
+		if v.Configuration != nil {
+			configuration, _ := json.Marshal(v.Configuration)
+			deploymentSpecification.Configuration = types.StringValue(string(configuration))
+		} else {
+			deploymentSpecification.Configuration = types.StringNull()
+		}
+
+		deploymentSpecifications = append(deploymentSpecifications, deploymentSpecification)
+	}
+	deploymentSpecificationsSetType, deploymentSpecificationsSetTypeDiag := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), deploymentSpecifications)
+	diag.Append(deploymentSpecificationsSetTypeDiag...)
+	return deploymentSpecificationsSetType
+}
+
+// managedTags returns the API tags which are tracked by the resource.
+func managedTags(apiTags []string, trackedTags []string) []string {
+	tracked := make(map[string]bool, len(trackedTags))
+	for _, t := range trackedTags {
+		tracked[t] = true
+	}
+
+	tags := make([]string, 0, len(trackedTags))
+	for _, t := range apiTags {
+		if tracked[t] {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// unmanagedTags returns the API tags which are not tracked by the resource.
+func unmanagedTags(apiTags []string, trackedTags []string) []string {
+	tracked := make(map[string]bool, len(trackedTags))
+	for _, t := range trackedTags {
+		tracked[t] = true
+	}
+
+	tags := make([]string, 0)
+	for _, t := range apiTags {
+		if !tracked[t] {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// splitVersionLabelTag extracts the version label from the API tags, returning the remaining tags.
+func splitVersionLabelTag(apiTags []string) (string, []string) {
+	versionLabel := ""
+	tags := make([]string, 0, len(apiTags))
+	for _, t := range apiTags {
+		if strings.HasPrefix(t, VERSION_LABEL_TAG_PREFIX) {
+			versionLabel = strings.TrimPrefix(t, VERSION_LABEL_TAG_PREFIX)
+		} else {
+			tags = append(tags, t)
+		}
+	}
+	return versionLabel, tags
+}
+
+func withoutTagPrefix(apiTags []string, prefix string) []string {
+	tags := make([]string, 0, len(apiTags))
+	for _, t := range apiTags {
+		if !strings.HasPrefix(t, prefix) {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// reservedTags returns the tags the resource derives from attributes other than tags.
+func (r *NvidiaCloudFunctionResource) reservedTags(data NvidiaCloudFunctionResourceModel) []string {
+	tags := make([]string, 0, 2)
+	if data.VersionLabel.ValueString() != "" {
+		tags = append(tags, VERSION_LABEL_TAG_PREFIX+data.VersionLabel.ValueString())
+	}
+	if r.providerVersion != "" {
+		tags = append(tags, PROVIDER_VERSION_TAG_PREFIX+r.providerVersion)
+	}
+	return tags
 }
 
 func updateTags(
@@ -272,6 +480,8 @@ func updateTags(
 	functionID string,
 	versionID string,
 	tagsRawData basetypes.SetValue,
+	reservedTags []string,
+	preservedTags []string,
 	diag *diag.Diagnostics,
 	client utils.NVCFClient,
 ) {
@@ -282,6 +492,12 @@ func updateTags(
 		return
 	}
 
+	// Reserved tags are replaced, not preserved.
+	_, preservedTags = splitVersionLabelTag(preservedTags)
+	preservedTags = withoutTagPrefix(preservedTags, PROVIDER_VERSION_TAG_PREFIX)
+	tags = append(tags, unmanagedTags(preservedTags, tags)...)
+	tags = append(tags, reservedTags...)
+
 	_, err := client.UpdateNvidiaCloudFunctionMetadata(ctx, functionID, versionID, utils.UpdateNvidiaCloudFunctionMetadataRequest{
 		Tags: tags,
 	})
@@ -380,8 +596,9 @@ func deploymentSpecificationsSchema() schema.SetNestedAttribute {
 					DeprecationMessage:  "This field is deprecated. Please use `clusters` instead.",
 				},
 				"instance_type": schema.StringAttribute{
-					MarkdownDescription: "NVCF Backend Instance Type.",
-					Required:            true,
+					MarkdownDescription: "NVCF Backend Instance Type, which also sets the CPU and memory available to the container. Required unless `auto_instance_type` is enabled, which resolves it when unset.",
+					Optional:            true,
+					Computed:            true,
 				},
 				"gpu_type": schema.StringAttribute{
 					MarkdownDescription: "GPU Type, GFN backend default is L40",
@@ -398,9 +615,6 @@ func deploymentSpecificationsSchema() schema.SetNestedAttribute {
 				"max_request_concurrency": schema.Int64Attribute{
 					MarkdownDescription: "Max Concurrency Count",
 					Required:            true,
-					PlanModifiers: []planmodifier.Int64{
-						int64planmodifier.RequiresReplace(),
-					},
 				},
 				"clusters": schema.SetAttribute{
 					ElementType:         types.StringType,
@@ -440,11 +654,8 @@ func resourcesSchema() schema.SetNestedAttribute {
 					Required:            true,
 				},
 				"uri": schema.StringAttribute{
-					MarkdownDescription: "Artifact URI",
+					MarkdownDescription: "Artifact URI. The NGC endpoint of the provider is prepended when it has no host.",
 					Required:            true,
-					PlanModifiers: []planmodifier.String{
-						custom_planmodifier.CloudFunctionArtifactUriPlanModifier{},
-					},
 				},
 			},
 		},
@@ -468,11 +679,8 @@ func modelsSchema() schema.SetNestedAttribute {
 					Required:            true,
 				},
 				"uri": schema.StringAttribute{
-					MarkdownDescription: "Artifact URI",
+					MarkdownDescription: "Artifact URI. The NGC endpoint of the provider is prepended when it has no host.",
 					Required:            true,
-					PlanModifiers: []planmodifier.String{
-						custom_planmodifier.CloudFunctionArtifactUriPlanModifier{},
-					},
 				},
 			},
 		},
@@ -515,7 +723,7 @@ func healthSchema() schema.SingleNestedAttribute {
 		},
 		Attributes: map[string]schema.Attribute{
 			"protocol": schema.StringAttribute{
-				MarkdownDescription: "HTTP/gPRC protocol type for health endpoint",
+				MarkdownDescription: "Protocol of the health endpoint, either \"HTTP\" or \"GRPC\". NVCF has no separate inference protocol setting, set \"GRPC\" for gRPC functions",
 				Required:            true,
 			},
 			"uri": schema.StringAttribute{
@@ -629,10 +837,14 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				},
 			},
 			"version_id": schema.StringAttribute{
-				Computed:            true,
-				MarkdownDescription: "Function Version ID",
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Function Version ID. When set together with `function_id` on create, the existing version is adopted " +
+					"instead of creating a new one, its configuration, including the tags, authorized parties and deployment specifications, must match the version. " +
+					"Changes requiring a new version are rejected while it's set. Remove it to create a new version",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
 			"deployment_id": schema.StringAttribute{
@@ -642,6 +854,10 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deployment_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Deployment status, e.g. \"ACTIVE\", or \"DEPLOYING\" when `wait_for_active` is disabled",
+			},
 			"function_name": schema.StringAttribute{
 				MarkdownDescription: "Function name",
 				Required:            true,
@@ -664,7 +880,7 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				},
 			},
 			"inference_port": schema.Int64Attribute{
-				MarkdownDescription: "Target port, will be service port or container port base on function-based",
+				MarkdownDescription: "Target port, will be service port or container port base on function-based. NVCF routes requests to this single port, a health listener on another port is set with `health.port`",
 				Optional:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplace(),
@@ -677,6 +893,13 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"container_image_tag_check": schema.StringAttribute{
+				MarkdownDescription: "How a `container_image` without an explicit tag or digest, which is pulled as `:latest` and so changes between deployments, is reported: " +
+					"\"WARN\", \"ERROR\", or \"OFF\" when the latest image is intended. Default is \"WARN\"",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("WARN"),
+			},
 			"container_environment": containerEnvironmentsSchema(),
 			"container_args": schema.StringAttribute{
 				MarkdownDescription: "Args to be passed when launching the container",
@@ -686,25 +909,42 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				},
 			},
 			"inference_url": schema.StringAttribute{
-				MarkdownDescription: "Service endpoint Path.",
-				Required:            true,
+				MarkdownDescription: "Service endpoint Path. Default is \"/\"",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("/"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"health_uri": schema.StringAttribute{
-				MarkdownDescription: "Service health endpoint Path. Default is \"/v2/health/ready\"",
+				MarkdownDescription: "Service health endpoint Path. Default is \"/v2/health/ready\" when `api_body_format` is \"PREDICT_V2\" and `health` is unset",
 				Optional:            true,
 				Computed:            true,
 				DeprecationMessage:  "The parameter is deprecated. Please replace it with `health`",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					custom_planmodifier.CloudFunctionHealthUriPlanModifier{},
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"health":    healthSchema(),
 			"resources": resourcesSchema(),
 			"models":    modelsSchema(),
+			"models_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of models attached to the function version",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"resources_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of resources attached to the function version",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"tags": schema.SetAttribute{
 				MarkdownDescription: "Tags of the function.",
 				ElementType:         types.StringType,
@@ -712,6 +952,10 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				Computed:            true,
 				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, nil)),
 			},
+			"version_label": schema.StringAttribute{
+				MarkdownDescription: "Human readable label of the function version, e.g. \"canary\" or \"stable\". Stored as a tag prefixed with \"" + VERSION_LABEL_TAG_PREFIX + "\".",
+				Optional:            true,
+			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the function",
 				Optional:            true,
@@ -732,7 +976,7 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				},
 			},
 			"api_body_format": schema.StringAttribute{
-				MarkdownDescription: "API Body Format. Default is \"CUSTOM\"",
+				MarkdownDescription: "API Body Format, \"CUSTOM\" or \"PREDICT_V2\" (Triton). Default is \"CUSTOM\"",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("CUSTOM"),
@@ -742,155 +986,798 @@ func (r *NvidiaCloudFunctionResource) Schema(ctx context.Context, req resource.S
 				},
 			},
 			"deployment_specifications": deploymentSpecificationsSchema(),
-			"secrets":                   secretsSchema(),
-			"authorized_parties":        authorizedPartiesSchema(),
-			"telemetries":               telemetriesSchema(),
+			"configuration_schema": schema.StringAttribute{
+				MarkdownDescription: "JSON schema of the Helm chart values. When set, the `configuration` of each deployment specification is validated against it at plan time. Supports the type, enum, properties, required, additionalProperties and items keywords",
+				Optional:            true,
+			},
+			"secrets":            secretsSchema(),
+			"authorized_parties": authorizedPartiesSchema(),
+			"telemetries":        telemetriesSchema(),
 			"keep_failed_resource": schema.BoolAttribute{
 				MarkdownDescription: "Don't delete failed resource. Default is \"false\"",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"warn_without_deployment": schema.BoolAttribute{
+				MarkdownDescription: "Warn when no `deployment_specifications` are set, since such a function version serves nothing. Leave it \"false\" to create a version without deploying it on purpose. Default is \"false\"",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_for_active": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the deployment to become ACTIVE on create and update. When \"false\", the apply returns right after the deployment request is accepted. Default is \"true\"",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"max_deployment_wait": schema.StringAttribute{
+				MarkdownDescription: "Longest time to wait for the deployment to become ACTIVE on create and update, on top of the resource timeouts, so a stuck deployment fails fast. " +
+					"A failed create cleans up per `keep_failed_resource`. Go duration format, e.g. \"10m\". Unset, only the timeouts bound the wait",
+				Optional: true,
+			},
+			"create_retry": schema.BoolAttribute{
+				MarkdownDescription: "Retry the deployment with backoff, within the create timeout, while it fails because all the GPU instances allocated to the org are in use. Default is \"false\"",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"auto_instance_type": schema.BoolAttribute{
+				MarkdownDescription: "Resolve the `instance_type` of the deployment specifications leaving it unset to the most cost-effective instance type of their `gpu_type`, " +
+					"i.e. the one with the fewest GPUs then CPU cores, among the cluster groups of their `backend` or `clusters`. Default is \"false\"",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"graceful_deletion": schema.BoolAttribute{
 				MarkdownDescription: "Enable graceful deletion of the function. Default is \"false\"",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"delete_all_versions_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Delete every version of the function on destroy, including the versions created outside of Terraform, instead of the managed version only. Their deployments are gracefully deleted first. Default is \"false\"",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Gracefully delete the deployment of the version on destroy, even when it's active, before the version is deleted. Default is \"false\"",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"last_recreate_reason": schema.ListAttribute{
+				MarkdownDescription: "Attributes whose change created the current function version, empty until the version is recreated",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"force_new_version": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value, changing it creates a new function version without any functional change, e.g. to re-pull a mutable image tag",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 				Update: true,
+				Delete: true,
 			}),
 		},
 	}
 }
 
-func (r *NvidiaCloudFunctionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	if req.ProviderData == nil {
+func (r *NvidiaCloudFunctionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NvidiaCloudFunctionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
-
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+	// Helm-based functions route inference traffic to a service inside the chart.
+	if !data.HelmChart.IsNull() && data.HelmChartServiceName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("helm_chart_service_name"),
+			"Missing Helm Chart Service Name",
+			"The helm_chart_service_name attribute is required when helm_chart is set, "+
+				"NVCF routes inference requests to this service of the chart.",
 		)
+	}
 
-		return
+	validateReservedTagPrefixes(data, &resp.Diagnostics)
+
+	if data.APIBodyFormat.ValueString() == "PREDICT_V2" {
+		validatePredictV2Endpoints(ctx, data, &resp.Diagnostics)
 	}
 
-	r.client = ngcClient.NVCFClient()
+	validateVersionID(data, &resp.Diagnostics)
+	validateMaxDeploymentWait(data, &resp.Diagnostics)
+	validatePorts(ctx, data, &resp.Diagnostics)
+	validateHealthProtocol(ctx, data, &resp.Diagnostics)
+	validateHelmContainerSettings(data, &resp.Diagnostics)
+	validateContainerImageTag(data, &resp.Diagnostics)
+	validateDeploymentTargets(ctx, data, &resp.Diagnostics)
+	validateInstanceTypes(ctx, data, &resp.Diagnostics)
+	validateConfigurationSchema(ctx, data, &resp.Diagnostics)
+
+	if data.WarnWithoutDeployment.ValueBool() {
+		validateDeploymentSpecificationsPresent(data, &resp.Diagnostics)
+	}
 }
 
-func (r *NvidiaCloudFunctionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_cloud_function"
+// reservedTagPrefixes maps the tag prefixes the provider stores its own metadata under to the setting managing them.
+var reservedTagPrefixes = []struct {
+	prefix string
+	hint   string
+}{
+	{VERSION_LABEL_TAG_PREFIX, "please set version_label instead"},
+	{PROVIDER_VERSION_TAG_PREFIX, "please set tag_provider_version in the provider configuration instead"},
 }
 
-//nolint:gocyclo
-func (r *NvidiaCloudFunctionResource) createOrUpdateRequest(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) utils.CreateNvidiaCloudFunctionRequest {
-	request := utils.CreateNvidiaCloudFunctionRequest{
-		FunctionName:  data.FunctionName.ValueString(),
-		InferencePort: int(data.InferencePort.ValueInt64()),
-		InferenceUrl:  data.InferenceUrl.ValueString(),
-		APIBodyFormat: data.APIBodyFormat.ValueString(),
-		FunctionType:  data.FunctionType.ValueString(),
+// validateReservedTagPrefixes rejects user tags colliding with the metadata the provider stores as tags,
+// they would be overwritten or misread as that metadata.
+func validateReservedTagPrefixes(data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if data.Tags.IsNull() || data.Tags.IsUnknown() {
+		return
 	}
 
-	if !data.HelmChart.IsNull() && !data.HelmChart.IsUnknown() {
-		request.HelmChart = data.HelmChart.ValueString()
+	for _, t := range data.Tags.Elements() {
+		tag, ok := t.(types.String)
+		if !ok || tag.IsUnknown() {
+			continue
+		}
+
+		for _, reserved := range reservedTagPrefixes {
+			if strings.HasPrefix(tag.ValueString(), reserved.prefix) {
+				diag.AddAttributeError(
+					path.Root("tags"),
+					"Reserved Tag Prefix",
+					fmt.Sprintf("The tag %s uses the reserved prefix %s, %s.", tag.ValueString(), reserved.prefix, reserved.hint),
+				)
+			}
+		}
 	}
+}
 
-	if !data.HelmChartServiceName.IsNull() && !data.HelmChartServiceName.IsUnknown() {
-		request.HelmChartServiceName = data.HelmChartServiceName.ValueString()
+// validateVersionID requires the function of an adopted version, versions are only addressable within their function.
+func validateVersionID(data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if data.VersionID.IsNull() || !data.FunctionID.IsNull() {
+		return
 	}
 
-	if !data.ContainerImage.IsNull() && !data.ContainerImage.IsUnknown() {
-		request.ContainerImage = data.ContainerImage.ValueString()
+	diag.AddAttributeError(
+		path.Root("function_id"),
+		"Missing Function ID",
+		"The function_id attribute is required when version_id is set, the version is adopted from this function.",
+	)
+}
+
+// validateMaxDeploymentWait rejects a max_deployment_wait which isn't a positive duration.
+func validateMaxDeploymentWait(data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if data.MaxDeploymentWait.IsNull() || data.MaxDeploymentWait.IsUnknown() {
+		return
 	}
 
-	if !data.ContainerArgs.IsNull() && !data.ContainerArgs.IsUnknown() {
-		request.ContainerArgs = data.ContainerArgs.ValueString()
+	if d, err := time.ParseDuration(data.MaxDeploymentWait.ValueString()); err != nil || d <= 0 {
+		diag.AddAttributeError(
+			path.Root("max_deployment_wait"),
+			"Invalid Max Deployment Wait",
+			fmt.Sprintf("Expected a positive duration such as \"10m\". Got: %q", data.MaxDeploymentWait.ValueString()),
+		)
 	}
+}
 
-	if !data.HealthUri.IsNull() && !data.HealthUri.IsUnknown() {
-		request.HealthUri = data.HealthUri.ValueString()
+// validatePorts rejects ports out of the TCP range, which NVCF would only reject once the function is created.
+func validatePorts(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	type portAttribute struct {
+		path  path.Path
+		value types.Int64
 	}
 
-	if !data.Description.IsNull() && !data.Description.IsUnknown() {
-		request.Description = data.Description.ValueString()
+	ports := []portAttribute{
+		{path.Root("inference_port"), data.InferencePort},
 	}
 
-	if !data.Secrets.IsNull() && !data.Secrets.IsUnknown() {
-		secrets := make([]NvidiaCloudFunctionResourceSecretModel, 0)
-		diag.Append(data.Secrets.ElementsAs(ctx, &secrets, false)...)
+	if !data.Health.IsNull() && !data.Health.IsUnknown() {
+		health := &NvidiaCloudFunctionResourceHealthModel{}
+		diag.Append(data.Health.As(ctx, health, basetypes.ObjectAsOptions{})...)
 
 		if diag.HasError() {
-			return utils.CreateNvidiaCloudFunctionRequest{}
+			return
 		}
+		ports = append(ports, portAttribute{path.Root("health").AtName("port"), health.Port})
+	}
 
-		for _, v := range secrets {
-			var secretValue interface{}
-			if v.Value.ValueString() != "" {
-				err := json.Unmarshal([]byte(v.Value.ValueString()), &secretValue)
-
-				// When the input is not a valid json, we will put it as string directly.
-				if err != nil {
-					request.Secrets = append(request.Secrets, utils.NvidiaCloudFunctionSecret{
-						Name:  v.Name.ValueString(),
-						Value: v.Value.ValueString(),
-					})
-				} else {
-					request.Secrets = append(request.Secrets, utils.NvidiaCloudFunctionSecret{
-						Name:  v.Name.ValueString(),
-						Value: secretValue,
-					})
-				}
-			}
+	for _, v := range ports {
+		if v.value.IsNull() || v.value.IsUnknown() {
+			continue
 		}
-	}
 
-	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
-		var tags []string
-		data.Tags.ElementsAs(ctx, &tags, true)
-		request.Tags = tags
+		if port := v.value.ValueInt64(); port < 1 || port > 65535 {
+			diag.AddAttributeError(
+				v.path,
+				"Invalid Port",
+				fmt.Sprintf("The port must be between 1 and 65535, got: %d", port),
+			)
+		}
 	}
+}
 
-	if !data.ContainerEnvironment.IsNull() && !data.ContainerEnvironment.IsUnknown() {
-		containerEnvironments := make([]NvidiaCloudFunctionResourceContainerEnvironmentModel, 0)
+var healthProtocols = []string{"HTTP", "GRPC"}
 
-		diag.Append(data.ContainerEnvironment.ElementsAs(ctx, &containerEnvironments, false)...)
+// validateHealthProtocol rejects health protocols NVCF can't probe. The health protocol is also
+// how NVCF tells gRPC functions apart, so a typo would silently set up the function for HTTP.
+func validateHealthProtocol(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if data.Health.IsNull() || data.Health.IsUnknown() {
+		return
+	}
 
-		if diag.HasError() {
-			return utils.CreateNvidiaCloudFunctionRequest{}
-		}
+	health := &NvidiaCloudFunctionResourceHealthModel{}
+	diag.Append(data.Health.As(ctx, health, basetypes.ObjectAsOptions{})...)
 
-		for _, v := range containerEnvironments {
-			request.ContainerEnvironment = append(request.ContainerEnvironment, utils.NvidiaCloudFunctionContainerEnvironment{
-				Key:   v.Key.ValueString(),
-				Value: v.Value.ValueString(),
-			})
-		}
+	if diag.HasError() || health.Protocol.IsUnknown() {
+		return
 	}
 
-	if !data.Health.IsNull() && !data.Health.IsUnknown() {
-		health := &NvidiaCloudFunctionResourceHealthModel{}
-		data.Health.As(ctx, health, basetypes.ObjectAsOptions{})
-		request.Health = &utils.NvidiaCloudFunctionHealth{
-			URI:                health.Uri.ValueString(),
-			Port:               int(health.Port.ValueInt64()),
-			Protocol:           health.Protocol.ValueString(),
-			Timeout:            health.Timeout.ValueString(),
-			ExpectedStatusCode: int(health.ExpectedStatusCode.ValueInt64()),
-		}
+	if !slices.Contains(healthProtocols, health.Protocol.ValueString()) {
+		diag.AddAttributeError(
+			path.Root("health").AtName("protocol"),
+			"Invalid Health Protocol",
+			fmt.Sprintf("The health protocol must be one of %s, got: %s", strings.Join(healthProtocols, ", "), health.Protocol.ValueString()),
+		)
 	}
+}
 
-	if !data.Resources.IsNull() && !data.Resources.IsUnknown() {
-		resources := make([]NvidiaCloudFunctionResourceResourceModel, 0)
+// validateHelmContainerSettings rejects the container settings NVCF ignores for helm-based functions,
+// whose containers are configured through the chart values instead.
+func validateHelmContainerSettings(data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if data.HelmChart.IsNull() {
+		return
+	}
 
-		diag.Append(data.Resources.ElementsAs(ctx, &resources, false)...)
+	containerSettings := []struct {
+		name  string
+		value attr.Value
+	}{
+		{"container_environment", data.ContainerEnvironment},
+		{"container_args", data.ContainerArgs},
+	}
+
+	for _, v := range containerSettings {
+		if !v.value.IsNull() {
+			diag.AddAttributeError(
+				path.Root(v.name),
+				"Unsupported Helm Chart Setting",
+				fmt.Sprintf("The %s attribute only applies to container-based functions, NVCF ignores it when helm_chart is set. "+
+					"Set the values of the chart through the configuration of the deployment_specifications instead.", v.name),
+			)
+		}
+	}
+}
+
+var containerImageTagChecks = []string{"WARN", "ERROR", "OFF"}
+
+// validateContainerImageTag reports a container image without tag or digest, NVCF pulls whatever is tagged latest
+// at the time of each deployment, so the same configuration may deploy different images.
+func validateContainerImageTag(data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	check := data.ContainerImageTagCheck.ValueString()
+	if data.ContainerImageTagCheck.IsUnknown() {
+		return
+	}
+
+	if data.ContainerImageTagCheck.IsNull() {
+		check = "WARN"
+	}
+
+	if !slices.Contains(containerImageTagChecks, check) {
+		diag.AddAttributeError(
+			path.Root("container_image_tag_check"),
+			"Invalid Container Image Tag Check",
+			fmt.Sprintf("The container image tag check must be one of %s, got: %s", strings.Join(containerImageTagChecks, ", "), check),
+		)
+		return
+	}
+
+	if check == "OFF" || data.ContainerImage.IsNull() || data.ContainerImage.IsUnknown() || hasImageTagOrDigest(data.ContainerImage.ValueString()) {
+		return
+	}
+
+	summary := "Container Image Without Tag"
+	detail := fmt.Sprintf("The container image %s has no tag or digest, it's pulled as :latest and may differ between deployments. "+
+		"Pin a tag or digest, or set container_image_tag_check to OFF when this is intended.", data.ContainerImage.ValueString())
+	if check == "ERROR" {
+		diag.AddAttributeError(path.Root("container_image"), summary, detail)
+	} else {
+		diag.AddAttributeWarning(path.Root("container_image"), summary, detail)
+	}
+}
+
+// hasImageTagOrDigest tells whether an image reference pins a tag or a digest. The port of a registry,
+// e.g. registry:5000/image, isn't a tag.
+func hasImageTagOrDigest(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+	return strings.Contains(image[strings.LastIndex(image, "/")+1:], ":")
+}
+
+// validateDeploymentSpecificationsPresent warns about a function version created without any deployment,
+// which exists but serves nothing.
+func validateDeploymentSpecificationsPresent(data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if data.DeploymentSpecifications.IsUnknown() || len(data.DeploymentSpecifications.Elements()) > 0 {
+		return
+	}
+
+	diag.AddAttributeWarning(
+		path.Root("deployment_specifications"),
+		"Cloud Function Without Deployment",
+		"No deployment_specifications are set, the function version will be created but serve no requests. "+
+			"Add deployment_specifications, or set warn_without_deployment to false when this is intended.",
+	)
+}
+
+// validateInstanceTypes requires the instance type of each deployment specification, unless it's resolved by auto_instance_type.
+func validateInstanceTypes(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if data.DeploymentSpecifications.IsNull() || data.DeploymentSpecifications.IsUnknown() || data.AutoInstanceType.ValueBool() || data.AutoInstanceType.IsUnknown() {
+		return
+	}
+
+	deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(data.DeploymentSpecifications.Elements()))
+	diag.Append(data.DeploymentSpecifications.ElementsAs(ctx, &deploymentSpecifications, false)...)
+
+	if diag.HasError() {
+		return
+	}
+
+	for _, v := range deploymentSpecifications {
+		if v.InstanceType.IsNull() {
+			diag.AddAttributeError(
+				path.Root("deployment_specifications"),
+				"Missing Instance Type",
+				fmt.Sprintf("The deployment specification of GPU %s must set instance_type, or auto_instance_type must be enabled to resolve it.", v.GpuType.ValueString()),
+			)
+		}
+	}
+}
+
+// validateDeploymentTargets ensures each deployment specification targets clusters or a backend,
+// NVCF rejects deployments without any of them.
+func validateDeploymentTargets(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if data.DeploymentSpecifications.IsNull() || data.DeploymentSpecifications.IsUnknown() {
+		return
+	}
+
+	deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(data.DeploymentSpecifications.Elements()))
+	diag.Append(data.DeploymentSpecifications.ElementsAs(ctx, &deploymentSpecifications, false)...)
+
+	if diag.HasError() {
+		return
+	}
+
+	for _, v := range deploymentSpecifications {
+		if v.Backend.IsUnknown() || v.Clusters.IsUnknown() {
+			continue
+		}
+
+		if v.Backend.ValueString() == "" && len(v.Clusters.Elements()) == 0 {
+			diag.AddAttributeError(
+				path.Root("deployment_specifications"),
+				"Missing Deployment Target",
+				fmt.Sprintf("The deployment specification of instance type %s must set clusters, or the deprecated backend, to be deployable.", v.InstanceType.ValueString()),
+			)
+		}
+	}
+}
+
+// validateConfigurationSchema checks the Helm values override of each deployment specification
+// against configuration_schema, so typos in the values keys fail the plan rather than the deployment.
+func validateConfigurationSchema(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	if data.ConfigurationSchema.IsNull() || data.ConfigurationSchema.IsUnknown() ||
+		data.DeploymentSpecifications.IsNull() || data.DeploymentSpecifications.IsUnknown() {
+		return
+	}
+
+	deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(data.DeploymentSpecifications.Elements()))
+	diag.Append(data.DeploymentSpecifications.ElementsAs(ctx, &deploymentSpecifications, false)...)
+
+	if diag.HasError() {
+		return
+	}
+
+	for _, v := range deploymentSpecifications {
+		if v.Configuration.IsNull() || v.Configuration.IsUnknown() {
+			continue
+		}
+
+		if err := utils.ValidateJSONSchema(data.ConfigurationSchema.ValueString(), v.Configuration.ValueString()); err != nil {
+			diag.AddAttributeError(
+				path.Root("deployment_specifications"),
+				"Invalid Deployment Configuration",
+				fmt.Sprintf("The configuration of instance type %s doesn't match configuration_schema: %s", v.InstanceType.ValueString(), err.Error()),
+			)
+		}
+	}
+}
+
+var predictV2InferenceUrlRegex = regexp.MustCompile(`^/v2/models/[^/]+(/versions/[^/]+)?/infer$`)
+
+// validatePredictV2Endpoints rejects endpoint overrides a Triton server can't serve.
+func validatePredictV2Endpoints(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	// The "/" default can't be served by Triton, the model path has to be set.
+	if data.InferenceUrl.IsNull() {
+		diag.AddAttributeError(
+			path.Root("inference_url"),
+			"Missing Inference URL",
+			"The PREDICT_V2 API body format expects inference_url in the form of \"/v2/models/<model>/infer\".",
+		)
+	}
+
+	if !data.InferenceUrl.IsNull() && !data.InferenceUrl.IsUnknown() && !predictV2InferenceUrlRegex.MatchString(data.InferenceUrl.ValueString()) {
+		diag.AddAttributeError(
+			path.Root("inference_url"),
+			"Invalid Inference URL",
+			fmt.Sprintf("The PREDICT_V2 API body format expects inference_url in the form of \"/v2/models/<model>/infer\", got: %s", data.InferenceUrl.ValueString()),
+		)
+	}
+
+	if !data.HealthUri.IsNull() && !data.HealthUri.IsUnknown() && !strings.HasPrefix(data.HealthUri.ValueString(), "/v2/health/") {
+		diag.AddAttributeError(
+			path.Root("health_uri"),
+			"Invalid Health URI",
+			fmt.Sprintf("The PREDICT_V2 API body format expects health_uri under \"/v2/health/\", got: %s", data.HealthUri.ValueString()),
+		)
+	}
+
+	if data.Health.IsNull() || data.Health.IsUnknown() {
+		return
+	}
+
+	health := &NvidiaCloudFunctionResourceHealthModel{}
+	diag.Append(data.Health.As(ctx, health, basetypes.ObjectAsOptions{})...)
+
+	if diag.HasError() {
+		return
+	}
+
+	if health.Protocol.ValueString() == "HTTP" && !health.Uri.IsUnknown() && !strings.HasPrefix(health.Uri.ValueString(), "/v2/health/") {
+		diag.AddAttributeError(
+			path.Root("health").AtName("uri"),
+			"Invalid Health URI",
+			fmt.Sprintf("The PREDICT_V2 API body format expects the HTTP health uri under \"/v2/health/\", got: %s", health.Uri.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan completes the artifact URIs and the instance types resolved on a prior apply, and records in
+// last_recreate_reason the attributes whose change replaces the function version.
+func (r *NvidiaCloudFunctionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to record on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Schemas are shared by every provider instance, the host of the artifact URIs is only known by the configured resource.
+	for _, artifactsPath := range []path.Path{path.Root("models"), path.Root("resources")} {
+		r.planArtifactUris(ctx, &resp.Plan, artifactsPath, &resp.Diagnostics)
+	}
+	planDeploymentInstanceTypes(ctx, req, resp)
+
+	var reason []string
+	if req.State.Raw.IsNull() {
+		// Terraform plans the create of a replacement again without the prior state,
+		// the reason is carried over from the replace plan through the private state.
+		value, diags := req.Private.GetKey(ctx, LAST_RECREATE_REASON_PRIVATE_KEY)
+		resp.Diagnostics.Append(diags...)
+		if len(value) > 0 {
+			if err := json.Unmarshal(value, &reason); err != nil {
+				tflog.Warn(ctx, "ignoring invalid recreate reason in private state", map[string]interface{}{"error": err.Error()})
+			}
+		}
+	} else {
+		var plan, state NvidiaCloudFunctionResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		addDeploymentSpecCountWarning(ctx, state.DeploymentSpecifications, plan.DeploymentSpecifications, &resp.Diagnostics)
+
+		reason = recreateReason(state, plan)
+		if len(reason) == 0 {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_recreate_reason"), state.LastRecreateReason)...)
+			return
+		}
+
+		addAdoptedVersionReplaceError(ctx, req.Config, state, reason, &resp.Diagnostics)
+
+		value, _ := json.Marshal(reason)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, LAST_RECREATE_REASON_PRIVATE_KEY, value)...)
+	}
+
+	if len(reason) == 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_recreate_reason"), types.ListNull(types.StringType))...)
+		return
+	}
+
+	lastRecreateReason, diags := types.ListValueFrom(ctx, types.StringType, reason)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_recreate_reason"), lastRecreateReason)...)
+}
+
+// addAdoptedVersionReplaceError fails a plan replacing the version pinned by version_id, the replacement would
+// delete that version and then fail to adopt it.
+func addAdoptedVersionReplaceError(ctx context.Context, config tfsdk.Config, state NvidiaCloudFunctionResourceModel, reason []string, diag *diag.Diagnostics) {
+	var versionID types.String
+	diag.Append(config.GetAttribute(ctx, path.Root("version_id"), &versionID)...)
+
+	// Pinning another version adopts that one instead.
+	if versionID.IsNull() || versionID.IsUnknown() || !versionID.Equal(state.VersionID) {
+		return
+	}
+
+	diag.AddAttributeError(
+		path.Root("version_id"),
+		"Cloud Function Version Replacement",
+		fmt.Sprintf("Changing %s requires a new version of function %s, which would delete version %s pinned by version_id "+
+			"before adopting it again. Remove version_id to create a new version.", strings.Join(reason, ", "), state.Id.ValueString(), versionID.ValueString()),
+	)
+}
+
+// planArtifactUris prepends the artifact host of the resource to the planned URIs of the models or resources without one.
+func (r *NvidiaCloudFunctionResource) planArtifactUris(ctx context.Context, plan *tfsdk.Plan, artifactsPath path.Path, diag *diag.Diagnostics) {
+	var artifacts types.Set
+	diag.Append(plan.GetAttribute(ctx, artifactsPath, &artifacts)...)
+	if diag.HasError() || artifacts.IsNull() || artifacts.IsUnknown() {
+		return
+	}
+
+	// Models and resources share the same attributes.
+	planned := make([]NvidiaCloudFunctionResourceModelModel, 0, len(artifacts.Elements()))
+	diag.Append(artifacts.ElementsAs(ctx, &planned, false)...)
+	if diag.HasError() {
+		return
+	}
+
+	modifier := custom_planmodifier.CloudFunctionArtifactUriPlanModifier{DefaultHost: r.artifactHost}
+	for i, v := range planned {
+		if v.Uri.IsUnknown() {
+			continue
+		}
+		modifierReq := planmodifier.StringRequest{PlanValue: v.Uri, StateValue: types.StringNull()}
+		modifierResp := &planmodifier.StringResponse{PlanValue: v.Uri}
+		modifier.PlanModifyString(ctx, modifierReq, modifierResp)
+		planned[i].Uri = modifierResp.PlanValue
+	}
+
+	value, diags := types.SetValueFrom(ctx, artifacts.ElementType(ctx), planned)
+	diag.Append(diags...)
+	diag.Append(plan.SetAttribute(ctx, artifactsPath, value)...)
+}
+
+// addDeploymentSpecCountWarning summarizes a change in the number of deployment specifications, which changes the
+// GPU capacity, and cost, of the function. It's only informational and never blocks the plan.
+func addDeploymentSpecCountWarning(ctx context.Context, state types.Set, plan types.Set, diag *diag.Diagnostics) {
+	if state.IsUnknown() || plan.IsUnknown() {
+		return
+	}
+
+	stateSpecs := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(state.Elements()))
+	planSpecs := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(plan.Elements()))
+	if !state.IsNull() && state.ElementsAs(ctx, &stateSpecs, false).HasError() {
+		return
+	}
+	if !plan.IsNull() && plan.ElementsAs(ctx, &planSpecs, false).HasError() {
+		return
+	}
+
+	if len(stateSpecs) == len(planSpecs) {
+		return
+	}
+
+	totalMaxInstances := func(specs []NvidiaCloudFunctionResourceDeploymentSpecificationModel) int64 {
+		var total int64
+		for _, v := range specs {
+			total += v.MaxInstances.ValueInt64()
+		}
+		return total
+	}
+
+	diag.AddAttributeWarning(
+		path.Root("deployment_specifications"),
+		"Deployment Specification Count Changed",
+		fmt.Sprintf("The deployment specifications change from %d to %d, the total max_instances from %d to %d.",
+			len(stateSpecs), len(planSpecs), totalMaxInstances(stateSpecs), totalMaxInstances(planSpecs)),
+	)
+}
+
+type versionAttribute struct {
+	name  string
+	state attr.Value
+	plan  attr.Value
+}
+
+// versionAttributes pairs the state and plan values of the attributes which are fixed for a function version.
+func versionAttributes(state NvidiaCloudFunctionResourceModel, plan NvidiaCloudFunctionResourceModel) []versionAttribute {
+	return []versionAttribute{
+		{"function_id", state.FunctionID, plan.FunctionID},
+		{"function_name", state.FunctionName, plan.FunctionName},
+		{"helm_chart", state.HelmChart, plan.HelmChart},
+		{"helm_chart_service_name", state.HelmChartServiceName, plan.HelmChartServiceName},
+		{"inference_port", state.InferencePort, plan.InferencePort},
+		{"container_image", state.ContainerImage, plan.ContainerImage},
+		{"container_args", state.ContainerArgs, plan.ContainerArgs},
+		{"container_environment", state.ContainerEnvironment, plan.ContainerEnvironment},
+		{"inference_url", state.InferenceUrl, plan.InferenceUrl},
+		{"health_uri", state.HealthUri, plan.HealthUri},
+		{"health", state.Health, plan.Health},
+		{"resources", state.Resources, plan.Resources},
+		{"models", state.Models, plan.Models},
+		{"description", state.Description, plan.Description},
+		{"function_type", state.FunctionType, plan.FunctionType},
+		{"api_body_format", state.APIBodyFormat, plan.APIBodyFormat},
+		{"telemetries", state.Telemetries, plan.Telemetries},
+		{"force_new_version", state.ForceNewVersion, plan.ForceNewVersion},
+	}
+}
+
+// recreateReason returns the changed attributes which require a new function version.
+func recreateReason(state NvidiaCloudFunctionResourceModel, plan NvidiaCloudFunctionResourceModel) []string {
+	var reason []string
+	for _, v := range versionAttributes(state, plan) {
+		if !v.plan.Equal(v.state) {
+			reason = append(reason, v.name)
+		}
+	}
+	return reason
+}
+
+// secretsRequest converts the configured secrets, JSON values are sent as JSON nodes and other values as strings.
+func secretsRequest(ctx context.Context, data types.Set, diag *diag.Diagnostics) []utils.NvidiaCloudFunctionSecret {
+	request := make([]utils.NvidiaCloudFunctionSecret, 0)
+
+	if data.IsNull() || data.IsUnknown() {
+		return request
+	}
+
+	secrets := make([]NvidiaCloudFunctionResourceSecretModel, 0)
+	diag.Append(data.ElementsAs(ctx, &secrets, false)...)
+
+	for _, v := range secrets {
+		var secretValue interface{}
+		if v.Value.ValueString() != "" {
+			err := json.Unmarshal([]byte(v.Value.ValueString()), &secretValue)
+
+			// When the input is not a valid json, we will put it as string directly.
+			if err != nil {
+				request = append(request, utils.NvidiaCloudFunctionSecret{
+					Name:  v.Name.ValueString(),
+					Value: v.Value.ValueString(),
+				})
+			} else {
+				request = append(request, utils.NvidiaCloudFunctionSecret{
+					Name:  v.Name.ValueString(),
+					Value: secretValue,
+				})
+			}
+		}
+	}
+	return request
+}
+
+func (r *NvidiaCloudFunctionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = ngcClient.NVCFClient()
+	r.providerVersion = ngcClient.ProviderVersion
+	r.deploymentDriftDetection = ngcClient.DeploymentDriftDetection
+	r.artifactHost = ngcClient.NgcEndpoint
+}
+
+func (r *NvidiaCloudFunctionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function"
+}
+
+//nolint:gocyclo
+func (r *NvidiaCloudFunctionResource) createOrUpdateRequest(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) utils.CreateNvidiaCloudFunctionRequest {
+	request := utils.CreateNvidiaCloudFunctionRequest{
+		FunctionName:  data.FunctionName.ValueString(),
+		InferencePort: int(data.InferencePort.ValueInt64()),
+		InferenceUrl:  data.InferenceUrl.ValueString(),
+		APIBodyFormat: data.APIBodyFormat.ValueString(),
+		FunctionType:  data.FunctionType.ValueString(),
+	}
+
+	if !data.HelmChart.IsNull() && !data.HelmChart.IsUnknown() {
+		request.HelmChart = data.HelmChart.ValueString()
+	}
+
+	if !data.HelmChartServiceName.IsNull() && !data.HelmChartServiceName.IsUnknown() {
+		request.HelmChartServiceName = data.HelmChartServiceName.ValueString()
+	}
+
+	if !data.ContainerImage.IsNull() && !data.ContainerImage.IsUnknown() {
+		request.ContainerImage = data.ContainerImage.ValueString()
+	}
+
+	if !data.ContainerArgs.IsNull() && !data.ContainerArgs.IsUnknown() {
+		request.ContainerArgs = data.ContainerArgs.ValueString()
+	}
+
+	// The deprecated health_uri is only sent without a health block, which supersedes it.
+	if !data.HealthUri.IsNull() && !data.HealthUri.IsUnknown() && (data.Health.IsNull() || data.Health.IsUnknown()) {
+		request.HealthUri = data.HealthUri.ValueString()
+	}
+
+	if !data.Description.IsNull() && !data.Description.IsUnknown() {
+		request.Description = data.Description.ValueString()
+	}
+
+	request.Secrets = secretsRequest(ctx, data.Secrets, diag)
+
+	if diag.HasError() {
+		return utils.CreateNvidiaCloudFunctionRequest{}
+	}
+
+	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
+		var tags []string
+		data.Tags.ElementsAs(ctx, &tags, true)
+		request.Tags = tags
+	}
+
+	request.Tags = append(request.Tags, r.reservedTags(data)...)
+
+	if !data.ContainerEnvironment.IsNull() && !data.ContainerEnvironment.IsUnknown() {
+		containerEnvironments := make([]NvidiaCloudFunctionResourceContainerEnvironmentModel, 0)
+
+		diag.Append(data.ContainerEnvironment.ElementsAs(ctx, &containerEnvironments, false)...)
+
+		if diag.HasError() {
+			return utils.CreateNvidiaCloudFunctionRequest{}
+		}
+
+		for _, v := range containerEnvironments {
+			request.ContainerEnvironment = append(request.ContainerEnvironment, utils.NvidiaCloudFunctionContainerEnvironment{
+				Key:   v.Key.ValueString(),
+				Value: v.Value.ValueString(),
+			})
+		}
+	}
+
+	if !data.Health.IsNull() && !data.Health.IsUnknown() {
+		health := &NvidiaCloudFunctionResourceHealthModel{}
+		data.Health.As(ctx, health, basetypes.ObjectAsOptions{})
+		request.Health = &utils.NvidiaCloudFunctionHealth{
+			URI:                health.Uri.ValueString(),
+			Port:               int(health.Port.ValueInt64()),
+			Protocol:           health.Protocol.ValueString(),
+			Timeout:            health.Timeout.ValueString(),
+			ExpectedStatusCode: int(health.ExpectedStatusCode.ValueInt64()),
+		}
+	}
+
+	if !data.Resources.IsNull() && !data.Resources.IsUnknown() {
+		resources := make([]NvidiaCloudFunctionResourceResourceModel, 0)
+
+		diag.Append(data.Resources.ElementsAs(ctx, &resources, false)...)
 
 		if diag.HasError() {
 			return utils.CreateNvidiaCloudFunctionRequest{}
@@ -905,123 +1792,554 @@ func (r *NvidiaCloudFunctionResource) createOrUpdateRequest(ctx context.Context,
 		}
 	}
 
-	if !data.Models.IsNull() && !data.Models.IsUnknown() {
-		models := make([]NvidiaCloudFunctionResourceModelModel, 0)
+	if !data.Models.IsNull() && !data.Models.IsUnknown() {
+		models := make([]NvidiaCloudFunctionResourceModelModel, 0)
+
+		diag.Append(data.Models.ElementsAs(ctx, &models, false)...)
+
+		if diag.HasError() {
+			return utils.CreateNvidiaCloudFunctionRequest{}
+		}
+
+		for _, v := range models {
+			request.Models = append(request.Models, utils.NvidiaCloudFunctionModel{
+				Name:    v.Name.ValueString(),
+				Version: v.Version.ValueString(),
+				URI:     v.Uri.ValueString(),
+			})
+		}
+	}
+
+	if !data.Telemetries.IsNull() && !data.Telemetries.IsUnknown() {
+		telemetries := &NvidiaCloudFunctionTelemetryModel{}
+		data.Telemetries.As(ctx, telemetries, basetypes.ObjectAsOptions{})
+		request.Telemetries = &utils.NvidiaCloudFunctionTelemetryIds{}
+
+		if !telemetries.LogsTelemetryId.IsNull() && !telemetries.LogsTelemetryId.IsUnknown() {
+			request.Telemetries.LogsTelemetryId = telemetries.LogsTelemetryId.ValueString()
+		}
+		if !telemetries.MetricsTelemetryId.IsNull() && !telemetries.MetricsTelemetryId.IsUnknown() {
+			request.Telemetries.MetricsTelemetryId = telemetries.MetricsTelemetryId.ValueString()
+		}
+		if !telemetries.TracesTelemetryId.IsNull() && !telemetries.TracesTelemetryId.IsUnknown() {
+			request.Telemetries.TracesTelemetryId = telemetries.TracesTelemetryId.ValueString()
+		}
+	}
+
+	return request
+}
+
+func (r *NvidiaCloudFunctionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NvidiaCloudFunctionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// An existing version is adopted rather than created, e.g. when migrating a version created outside Terraform.
+	if !data.VersionID.IsNull() && !data.VersionID.IsUnknown() {
+		r.adoptVersion(ctx, &data, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	request := r.createOrUpdateRequest(ctx, data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A new version of an existing function can only be created by the account owning it.
+	if data.FunctionID.ValueString() != "" {
+		r.checkFunctionManageable(ctx, data.FunctionID.ValueString(), &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var createNvidiaCloudFunctionResponse, err = r.client.CreateNvidiaCloudFunction(
+		ctx,
+		data.FunctionID.ValueString(),
+		request,
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create Cloud Function",
+			err.Error(),
+		)
+		return
+	}
+
+	function := createNvidiaCloudFunctionResponse.Function
+
+	authorizedAccounts := updateFunctionAuthorizedParties(ctx, function.ID, function.VersionID, data.AuthorizedParties, &resp.Diagnostics, *r.client)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(data.DeploymentSpecifications.Elements()) == 0 {
+		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &function, nil, &authorizedAccounts)
+	} else {
+		deployment := r.createDeployment(ctx, data, &resp.Diagnostics, function)
+
+		if resp.Diagnostics.HasError() {
+			r.deleteFailedDeploymentVersion(ctx, data.KeepFailedResource.ValueBool(), function.ID, function.VersionID, &resp.Diagnostics)
+			return
+		}
+		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &function, &deployment, &authorizedAccounts)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// adoptVersion reads an existing version into the planned model, it fails when the version doesn't exist
+// or differs from the configuration.
+func (r *NvidiaCloudFunctionResource) adoptVersion(ctx context.Context, data *NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) {
+	plan := *data
+	functionID := data.FunctionID.ValueString()
+	versionID := data.VersionID.ValueString()
+
+	getFunctionVersionResponse, err := r.client.GetNvidiaCloudFunctionVersion(ctx, functionID, versionID)
+
+	if err != nil {
+		if utils.IsNotFound(err) {
+			diag.AddAttributeError(
+				path.Root("version_id"),
+				"Cloud Function Version Not Found",
+				fmt.Sprintf("Version %s of function %s doesn't exist, remove version_id to create a new version.", versionID, functionID),
+			)
+			return
+		}
+
+		diag.AddError(
+			"Failed to Get Cloud Function version",
+			err.Error(),
+		)
+		return
+	}
+
+	function := &getFunctionVersionResponse.Function
+
+	addSharedFunctionError(function, diag)
+
+	if diag.HasError() {
+		return
+	}
+
+	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, functionID, versionID)
+
+	if err != nil {
+		diag.AddError(
+			"Failed to read Cloud Function deployment",
+			err.Error(),
+		)
+		return
+	}
+
+	authorizedAccounts, err := r.client.GetFunctionAuthorization(ctx, functionID, versionID)
+
+	if err != nil {
+		diag.AddError(
+			"Failed to get Cloud Function authorization",
+			err.Error(),
+		)
+		return
+	}
+
+	// A version which isn't deployed doesn't match configured deployment specifications.
+	clearDeletedDeployment(ctx, data, &readNvidiaCloudFunctionDeploymentResponse.Deployment)
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, diag, data, function, &readNvidiaCloudFunctionDeploymentResponse.Deployment, authorizedAccounts)
 
-		diag.Append(data.Models.ElementsAs(ctx, &models, false)...)
+	if mismatch := adoptedVersionMismatch(*data, plan); len(mismatch) > 0 {
+		diag.AddAttributeError(
+			path.Root("version_id"),
+			"Cloud Function Version Mismatch",
+			fmt.Sprintf("Version %s of function %s doesn't match the configured %s, an adopted version is taken as is. "+
+				"Align the configuration with the version, or remove version_id to create a new version.", versionID, functionID, strings.Join(mismatch, ", ")),
+		)
+	}
+}
 
-		if diag.HasError() {
-			return utils.CreateNvidiaCloudFunctionRequest{}
+// adoptedVersionMismatch returns the configured version attributes which differ from the adopted version,
+// attributes left to NVCF are not compared. The version is adopted as is, so the tags, authorized parties and
+// deployment which could be updated in place must match as well.
+func adoptedVersionMismatch(adopted NvidiaCloudFunctionResourceModel, plan NvidiaCloudFunctionResourceModel) []string {
+	var mismatch []string
+	for _, v := range versionAttributes(adopted, plan) {
+		if v.plan.IsNull() || v.plan.IsUnknown() {
+			continue
 		}
+		if !v.plan.Equal(v.state) {
+			mismatch = append(mismatch, v.name)
+		}
+	}
 
-		for _, v := range models {
-			request.Models = append(request.Models, utils.NvidiaCloudFunctionModel{
-				Name:    v.Name.ValueString(),
-				Version: v.Version.ValueString(),
-				URI:     v.Uri.ValueString(),
-			})
+	for _, v := range []versionAttribute{
+		{"tags", adopted.Tags, plan.Tags},
+		{"authorized_parties", adopted.AuthorizedParties, plan.AuthorizedParties},
+		{"deployment_specifications", adopted.DeploymentSpecifications, plan.DeploymentSpecifications},
+	} {
+		if !knownValuesMatch(v.plan, v.state) {
+			mismatch = append(mismatch, v.name)
 		}
 	}
+	return mismatch
+}
 
-	if !data.Telemetries.IsNull() && !data.Telemetries.IsUnknown() {
-		telemetries := &NvidiaCloudFunctionTelemetryModel{}
-		data.Telemetries.As(ctx, telemetries, basetypes.ObjectAsOptions{})
-		request.Telemetries = &utils.NvidiaCloudFunctionTelemetryIds{}
+// knownValuesMatch reports whether the planned value equals the actual one, unknown values, e.g. the
+// computed attributes of a deployment specification, match anything.
+func knownValuesMatch(planned attr.Value, actual attr.Value) bool {
+	if planned.IsUnknown() {
+		return true
+	}
 
-		if !telemetries.LogsTelemetryId.IsNull() && !telemetries.LogsTelemetryId.IsUnknown() {
-			request.Telemetries.LogsTelemetryId = telemetries.LogsTelemetryId.ValueString()
+	switch planned := planned.(type) {
+	case types.Set:
+		actual, ok := actual.(types.Set)
+		if !ok || planned.IsNull() || actual.IsNull() || len(planned.Elements()) != len(actual.Elements()) {
+			return planned.Equal(actual)
 		}
-		if !telemetries.MetricsTelemetryId.IsNull() && !telemetries.MetricsTelemetryId.IsUnknown() {
-			request.Telemetries.MetricsTelemetryId = telemetries.MetricsTelemetryId.ValueString()
+
+		// Set elements have no identity, each planned element is matched with a distinct actual one.
+		matched := make([]bool, len(actual.Elements()))
+		for _, p := range planned.Elements() {
+			found := false
+			for i, a := range actual.Elements() {
+				if !matched[i] && knownValuesMatch(p, a) {
+					matched[i], found = true, true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
 		}
-		if !telemetries.TracesTelemetryId.IsNull() && !telemetries.TracesTelemetryId.IsUnknown() {
-			request.Telemetries.TracesTelemetryId = telemetries.TracesTelemetryId.ValueString()
+		return true
+	case types.Object:
+		actual, ok := actual.(types.Object)
+		if !ok || planned.IsNull() || actual.IsNull() {
+			return planned.Equal(actual)
+		}
+
+		for name, p := range planned.Attributes() {
+			a, ok := actual.Attributes()[name]
+			if !ok || !knownValuesMatch(p, a) {
+				return false
+			}
 		}
+		return true
+	default:
+		return planned.Equal(actual)
 	}
+}
 
-	return request
+// checkFunctionManageable fails when the existing function is owned by a different account.
+func (r *NvidiaCloudFunctionResource) checkFunctionManageable(ctx context.Context, functionID string, diag *diag.Diagnostics) {
+	listNvidiaCloudFunctionVersionsResponse, err := r.client.ListNvidiaCloudFunctionVersions(ctx, functionID)
+
+	if err != nil {
+		diag.AddError(
+			"Failed to list Cloud Function versions",
+			err.Error(),
+		)
+		return
+	}
+
+	if len(listNvidiaCloudFunctionVersionsResponse.Functions) > 0 {
+		addSharedFunctionError(&listNvidiaCloudFunctionVersionsResponse.Functions[0], diag)
+	}
 }
 
-func (r *NvidiaCloudFunctionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+// priorArtifactUris maps the names of the models or resources in the prior state to their URI.
+func priorArtifactUris(ctx context.Context, artifacts types.Set) map[string]string {
+	uris := map[string]string{}
+	if artifacts.IsNull() || artifacts.IsUnknown() {
+		return uris
+	}
+
+	// Models and resources share the same attributes.
+	prior := make([]NvidiaCloudFunctionResourceModelModel, 0, len(artifacts.Elements()))
+	if diags := artifacts.ElementsAs(ctx, &prior, false); diags.HasError() {
+		return uris
+	}
+
+	for _, v := range prior {
+		uris[v.Name.ValueString()] = v.Uri.ValueString()
+	}
+	return uris
+}
+
+// artifactUri keeps the prior URI of an artifact when NVCF returns it normalized, e.g. without
+// the registry host, so only an actual change of the artifact, or of its version, shows as drift.
+func artifactUri(priorUris map[string]string, name string, uri string) string {
+	if prior, ok := priorUris[name]; ok && artifactPath(prior) == artifactPath(uri) {
+		return prior
+	}
+	return uri
+}
+
+func artifactPath(uri string) string {
+	if u, err := url.Parse(uri); err == nil && u.Host != "" {
+		uri = u.Path
+	}
+	return strings.Trim(uri, "/")
+}
+
+// addSharedFunctionError explains why a function shared by a different account can't be modified,
+// rather than letting the mutation fail with a generic permission error.
+func addSharedFunctionError(function *utils.NvidiaCloudFunctionInfo, diag *diag.Diagnostics) {
+	if !function.OwnedByDifferentAccount {
+		return
+	}
+
+	diag.AddError(
+		"Cloud Function Not Manageable",
+		fmt.Sprintf("Function %s is owned by a different account (NCA ID %s) and only shared with this org. "+
+			"Shared functions can be read and invoked, but only the owning account can modify them.", function.ID, function.NcaID),
+	)
+}
+
+func (r *NvidiaCloudFunctionResource) deleteFailedDeploymentVersion(ctx context.Context, keepFailedResource bool, functionID string, versionID string, diag *diag.Diagnostics) {
+	tflog.Error(ctx, "failed to deploy the new version.")
+	if !keepFailedResource {
+		// The create context may already be expired when the deployment timed out,
+		// so the cleanup gets its own time budget.
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), FAILED_VERSION_CLEANUP_TIMEOUT_SEC*time.Second)
+		defer cancel()
+
+		err := r.client.DeleteNvidiaCloudFunctionVersion(cleanupCtx, functionID, versionID)
+		if err != nil {
+			diag.AddError(
+				"Failed to delete failed Cloud Function deployment",
+				err.Error(),
+			)
+			return
+		}
+		tflog.Info(ctx, "deleted the failed function deployment")
+	}
+}
+
+func (r *NvidiaCloudFunctionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data NvidiaCloudFunctionResourceModel
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	createTimeout, diags := data.Timeouts.Create(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
-	resp.Diagnostics.Append(diags...)
+	var getFunctionVersionResponse, err = r.client.GetNvidiaCloudFunctionVersion(ctx, data.Id.ValueString(), data.VersionID.ValueString())
+
+	if err != nil {
+		// Check if the error indicates that the version, or the whole function, was not found
+		if utils.IsNotFound(err) || strings.Contains(err.Error(), "Not found") {
+			// Resource does not exist anymore, remove from state
+			tflog.Warn(ctx, fmt.Sprintf("Cloud Function version %s/%s no longer exists, removing from state", data.Id.ValueString(), data.VersionID.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		// For other errors, report them as usual
+		resp.Diagnostics.AddError(
+			"Failed to Get Cloud Function version",
+			err.Error(),
+		)
+		return
+	}
+
+	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, data.Id.ValueString(), data.VersionID.ValueString())
+
+	if err != nil {
+		// FIXME: extract error messsage to constants.
+		if !strings.Contains(err.Error(), "failed to find function deployment") {
+			resp.Diagnostics.AddError(
+				"Failed to read Cloud Function deployment",
+				err.Error(),
+			)
+		}
+	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, createTimeout)
-	defer cancel()
+	authorizedAccounts, err := r.client.GetFunctionAuthorization(ctx, data.Id.ValueString(), data.VersionID.ValueString())
 
-	request := r.createOrUpdateRequest(ctx, data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to get Cloud Function authorization",
+			err.Error(),
+		)
+		return
+	}
+
+	if r.deploymentDriftDetection {
+		clearDeletedDeployment(ctx, &data, &readNvidiaCloudFunctionDeploymentResponse.Deployment)
+	}
+
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &getFunctionVersionResponse.Function, &readNvidiaCloudFunctionDeploymentResponse.Deployment, authorizedAccounts)
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// clearDeletedDeployment empties the deployment specifications of a deployed version whose deployment is gone,
+// e.g. deleted outside of Terraform, so the plan deploys the version again.
+func clearDeletedDeployment(ctx context.Context, data *NvidiaCloudFunctionResourceModel, deployment *utils.NvidiaCloudFunctionDeployment) {
+	if deployment.DeploymentID != "" || len(data.DeploymentSpecifications.Elements()) == 0 {
+		return
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("Cloud Function version %s/%s is no longer deployed", data.Id.ValueString(), data.VersionID.ValueString()))
+	data.DeploymentSpecifications = types.SetValueMust(deploymentSpecificationsSchema().NestedObject.Type(), []attr.Value{})
+}
+
+// Update changes the tags, secrets and deployment of the version in place, any other change
+// replaces the version, see ModifyPlan.
+func (r *NvidiaCloudFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state NvidiaCloudFunctionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var createNvidiaCloudFunctionResponse, err = r.client.CreateNvidiaCloudFunction(
-		ctx,
-		data.FunctionID.ValueString(),
-		request,
-	)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	getFunctionVersionResponse, err := r.client.GetNvidiaCloudFunctionVersion(ctx, state.Id.ValueString(), state.VersionID.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to create Cloud Function",
+			"Failed to get Cloud Function",
 			err.Error(),
 		)
 		return
 	}
 
-	function := createNvidiaCloudFunctionResponse.Function
+	function := &getFunctionVersionResponse.Function
 
-	authorizedAccounts := updateFunctionAuthorizedParties(ctx, function.ID, function.VersionID, data.AuthorizedParties, &resp.Diagnostics, *r.client)
+	addSharedFunctionError(function, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if len(data.DeploymentSpecifications.Elements()) == 0 {
-		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &function, nil, &authorizedAccounts)
-	} else {
-		deployment := r.createDeployment(ctx, data, &resp.Diagnostics, function)
+	// Update tags if they've changed, keeping the tags not managed by this resource.
+	providerVersionOutdated := r.providerVersion != "" && !slices.Contains(function.Tags, PROVIDER_VERSION_TAG_PREFIX+r.providerVersion)
+	if !plan.Tags.Equal(state.Tags) || !plan.VersionLabel.Equal(state.VersionLabel) || providerVersionOutdated {
+		stateTags := make([]string, 0, len(state.Tags.Elements()))
+		resp.Diagnostics.Append(state.Tags.ElementsAs(ctx, &stateTags, false)...)
+		updateTags(ctx, state.Id.ValueString(), state.VersionID.ValueString(), plan.Tags, r.reservedTags(plan), unmanagedTags(function.Tags, stateTags), &resp.Diagnostics, *r.client)
 
 		if resp.Diagnostics.HasError() {
-			r.deleteFailedDeploymentVersion(ctx, data.KeepFailedResource.ValueBool(), function.ID, function.VersionID, &resp.Diagnostics)
 			return
 		}
-		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &function, &deployment, &authorizedAccounts)
+
+		getFunctionVersionResponse, err = r.client.GetNvidiaCloudFunctionVersion(ctx, state.Id.ValueString(), state.VersionID.ValueString())
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to get Cloud Function",
+				err.Error(),
+			)
+			return
+		}
+
+		function = &getFunctionVersionResponse.Function
 	}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
+	// Secrets are rotated in place, keeping the version and its running instances.
+	if !plan.Secrets.Equal(state.Secrets) {
+		secrets := secretsRequest(ctx, plan.Secrets, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		err := r.client.UpdateFunctionSecrets(ctx, state.Id.ValueString(), state.VersionID.ValueString(), utils.UpdateNvidiaCloudFunctionSecretsRequest{Secrets: secrets})
 
-func (r *NvidiaCloudFunctionResource) deleteFailedDeploymentVersion(ctx context.Context, keepFailedResource bool, functionID string, versionID string, diag *diag.Diagnostics) {
-	tflog.Error(ctx, "failed to deploy the new version.")
-	if !keepFailedResource {
-		err := r.client.DeleteNvidiaCloudFunctionVersion(ctx, functionID, versionID)
 		if err != nil {
-			diag.AddError(
-				"Failed to delete failed Cloud Function deployment",
+			resp.Diagnostics.AddError(
+				"Failed to update Cloud Function secrets",
 				err.Error(),
 			)
 			return
 		}
-		tflog.Info(ctx, "deleted the failed function deployment")
 	}
+
+	authorizedAccounts := updateFunctionAuthorizedParties(ctx, function.ID, function.VersionID, plan.AuthorizedParties, &resp.Diagnostics, *r.client)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(plan.DeploymentSpecifications.Elements()) == 0 {
+		_, err := r.client.DeleteNvidiaCloudFunctionDeployment(ctx, state.Id.ValueString(), state.VersionID.ValueString(), plan.GracefulDeletion.ValueBool())
+		// The case we still save state, since the deployment is disabled and user can delete the version manually.
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Failed to delete Cloud Function Deployment %s", plan.VersionID.ValueString()),
+				err.Error(),
+			)
+		} else {
+			waitForDeploymentDeleted(ctx, r.client, state.Id.ValueString(), state.VersionID.ValueString(), &resp.Diagnostics)
+		}
+		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &plan, function, nil, &authorizedAccounts)
+	} else if len(state.DeploymentSpecifications.Elements()) == 0 {
+		// The version isn't deployed, e.g. its deployment was deleted outside of Terraform.
+		deployment := r.createDeployment(ctx, plan, &resp.Diagnostics, *function)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &plan, function, &deployment, &authorizedAccounts)
+	} else {
+		deployment := r.updateDeployment(ctx, plan, state, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &plan, function, &deployment, &authorizedAccounts)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func (r *NvidiaCloudFunctionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+func (r *NvidiaCloudFunctionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data NvidiaCloudFunctionResourceModel
 
 	// Read Terraform prior state data into the model
@@ -1031,182 +2349,358 @@ func (r *NvidiaCloudFunctionResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
-	var getFunctionVersionResponse, err = r.client.GetNvidiaCloudFunctionVersion(ctx, data.Id.ValueString(), data.VersionID.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if data.GracefulDeletion.ValueBool() {
+		_, err := r.client.DeleteNvidiaCloudFunctionDeployment(ctx, data.Id.ValueString(), data.VersionID.ValueString(), data.GracefulDeletion.ValueBool())
+		// The case we still save state, since the deployment is disabled and user can delete the version manually.
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Failed to delete Cloud Function Deployment %s", data.VersionID.ValueString()),
+				err.Error(),
+			)
+		} else {
+			waitForDeploymentDeleted(ctx, r.client, data.Id.ValueString(), data.VersionID.ValueString(), &resp.Diagnostics)
+		}
+	} else if data.ForceDestroy.ValueBool() {
+		r.deleteExistingDeployment(ctx, data.Id.ValueString(), data.VersionID.ValueString(), &resp.Diagnostics)
+	} else {
+		r.cancelPendingDeployment(ctx, data.Id.ValueString(), data.VersionID.ValueString(), &resp.Diagnostics)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DeleteAllVersionsOnDestroy.ValueBool() {
+		r.deleteOtherVersions(ctx, data.Id.ValueString(), data.VersionID.ValueString(), &resp.Diagnostics)
 
-	if err != nil {
-		// Check if the error indicates that the resource was not found
-		if strings.Contains(err.Error(), "Not found") {
-			// Resource does not exist anymore, remove from state
-			tflog.Warn(ctx, fmt.Sprintf("Cloud Function version %s/%s no longer exists, removing from state", data.Id.ValueString(), data.VersionID.ValueString()))
-			resp.State.RemoveResource(ctx)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+	}
 
-		// For other errors, report them as usual
+	// NVCF has no separate function deletion, a function goes away along with its last version
+	// and there is no way to keep it, nor its ID, without any version.
+	err := r.client.DeleteNvidiaCloudFunctionVersion(ctx, data.Id.ValueString(), data.VersionID.ValueString())
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to Get Cloud Function version",
+			fmt.Sprintf("Failed to delete Cloud Function version %s", data.VersionID.ValueString()),
 			err.Error(),
 		)
+	}
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
+}
 
-	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, data.Id.ValueString(), data.VersionID.ValueString())
+// deleteOtherVersions deletes every version of the function but the managed one, e.g. the versions
+// created out-of-band, so destroying the managed version last removes the whole function. A version
+// can't be deleted while deployed, so its deployment is gracefully deleted first whatever its state.
+func (r *NvidiaCloudFunctionResource) deleteOtherVersions(ctx context.Context, functionID string, versionID string, diag *diag.Diagnostics) {
+	listNvidiaCloudFunctionVersionsResponse, err := r.client.ListNvidiaCloudFunctionVersions(ctx, functionID)
 
 	if err != nil {
-		// FIXME: extract error messsage to constants.
-		if err.Error() != "failed to find function deployment" {
-			resp.Diagnostics.AddError(
-				"Failed to read Cloud Function deployment",
+		diag.AddError(
+			"Failed to list Cloud Function versions",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, f := range listNvidiaCloudFunctionVersionsResponse.Functions {
+		if f.VersionID == versionID {
+			continue
+		}
+
+		r.deleteExistingDeployment(ctx, functionID, f.VersionID, diag)
+
+		if diag.HasError() {
+			return
+		}
+
+		tflog.Info(ctx, "deleting function version", map[string]interface{}{
+			"function_id": functionID,
+			"version_id":  f.VersionID,
+		})
+
+		err := r.client.DeleteNvidiaCloudFunctionVersion(ctx, functionID, f.VersionID)
+		if err != nil && !utils.IsNotFound(err) {
+			diag.AddError(
+				fmt.Sprintf("Failed to delete Cloud Function version %s", f.VersionID),
 				err.Error(),
 			)
+			return
 		}
 	}
+}
 
-	if resp.Diagnostics.HasError() {
+// cancelPendingDeployment deletes a deployment still in DEPLOYING state, so destroying the
+// version doesn't wait for a deployment that may never complete.
+func (r *NvidiaCloudFunctionResource) cancelPendingDeployment(ctx context.Context, functionID string, versionID string, diag *diag.Diagnostics) {
+	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, functionID, versionID)
+
+	// The version is deleted regardless, a failed lookup only means nothing is cancelled.
+	if err != nil || readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus != "DEPLOYING" {
 		return
 	}
 
-	authorizedAccounts, err := r.client.GetFunctionAuthorization(ctx, data.Id.ValueString(), data.VersionID.ValueString())
+	tflog.Info(ctx, "cancelling in-progress deployment", map[string]interface{}{
+		"function_id": functionID,
+		"version_id":  versionID,
+	})
 
+	_, err = r.client.DeleteNvidiaCloudFunctionDeployment(ctx, functionID, versionID, false)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to get Cloud Function authorization",
+		diag.AddError(
+			fmt.Sprintf("Failed to cancel Cloud Function Deployment %s", versionID),
 			err.Error(),
 		)
 		return
 	}
 
-	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &data, &getFunctionVersionResponse.Function, &readNvidiaCloudFunctionDeploymentResponse.Deployment, authorizedAccounts)
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	waitForDeploymentDeleted(ctx, r.client, functionID, versionID, diag)
 }
 
-// TODO: Support deployment update, not recreate new function version.
-func (r *NvidiaCloudFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan, state NvidiaCloudFunctionResourceModel
+// waitForDeploymentDeleted waits for a deleted deployment to be gone, so its version can be deleted next.
+func waitForDeploymentDeleted(ctx context.Context, client *utils.NVCFClient, functionID string, versionID string, diag *diag.Diagnostics) {
+	if err := client.WaitingDeploymentDeleted(ctx, functionID, versionID); err != nil {
+		diag.AddError(
+			fmt.Sprintf("Failed to wait for the deletion of Cloud Function Deployment %s", versionID),
+			err.Error(),
+		)
+	}
+}
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+// deleteExistingDeployment gracefully deletes the deployment of a version whatever its state, so a version
+// serving requests can be destroyed.
+func (r *NvidiaCloudFunctionResource) deleteExistingDeployment(ctx context.Context, functionID string, versionID string, diag *diag.Diagnostics) {
+	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, functionID, versionID)
 
-	if resp.Diagnostics.HasError() {
+	if err != nil {
+		diag.AddError(
+			"Failed to read Cloud Function deployment",
+			err.Error(),
+		)
 		return
 	}
 
-	updateTimeout, diags := plan.Timeouts.Update(ctx, DEFAULT_TIMEOUT_SEC*time.Second)
-	resp.Diagnostics.Append(diags...)
-
-	if resp.Diagnostics.HasError() {
+	// NVCF answers with an empty deployment when the version isn't deployed.
+	if readNvidiaCloudFunctionDeploymentResponse.Deployment.DeploymentID == "" {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
-	defer cancel()
+	tflog.Info(ctx, "deleting deployment before the version", map[string]interface{}{
+		"function_id": functionID,
+		"version_id":  versionID,
+		"status":      readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus,
+	})
 
-	// Update tags if they've changed
-	if !plan.Tags.Equal(state.Tags) {
-		updateTags(ctx, state.Id.ValueString(), state.VersionID.ValueString(), plan.Tags, &resp.Diagnostics, *r.client)
+	_, err = r.client.DeleteNvidiaCloudFunctionDeployment(ctx, functionID, versionID, true)
+	if err != nil && !utils.IsNotFound(err) {
+		diag.AddError(
+			fmt.Sprintf("Failed to delete Cloud Function Deployment %s", versionID),
+			err.Error(),
+		)
+		return
 	}
 
-	getFunctionVersionResponse, err := r.client.GetNvidiaCloudFunctionVersion(ctx, state.Id.ValueString(), state.VersionID.ValueString())
+	waitForDeploymentDeleted(ctx, r.client, functionID, versionID, diag)
+}
+
+func (r *NvidiaCloudFunctionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	functionID, versionID, err := parseFunctionImportID(req.ID)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to get Cloud Function",
+			"Unexpected Import Identifier",
 			err.Error(),
 		)
+		return
 	}
 
-	function := &getFunctionVersionResponse.Function
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), functionID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version_id"), versionID)...)
+}
 
-	authorizedAccounts := updateFunctionAuthorizedParties(ctx, function.ID, function.VersionID, plan.AuthorizedParties, &resp.Diagnostics, *r.client)
+// parseFunctionImportID splits an import identifier of the function_id,version_id format.
+func parseFunctionImportID(id string) (functionID string, versionID string, err error) {
+	idParts := strings.Split(id, ",")
 
-	if resp.Diagnostics.HasError() {
+	for i := range idParts {
+		idParts[i] = strings.TrimSpace(idParts[i])
+	}
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf(
+			"expected import identifier with format: function_id,version_id, "+
+				"e.g. \"033c9664-f5b0-4bd2-8918-5aab085fc8db,f0cc4c95-108c-471a-b52c-a2bd5c0024c2\". Got %d part(s) in: %q",
+			len(idParts), id,
+		)
+	}
+
+	return idParts[0], idParts[1], nil
+}
+
+func (r *NvidiaCloudFunctionResource) prepareDeploymentSpecifications(
+	ctx context.Context,
+	data NvidiaCloudFunctionResourceModel,
+	deployed types.Set,
+	diag *diag.Diagnostics,
+) []utils.NvidiaCloudFunctionDeploymentSpecification {
+	deploymentSpecifications := deploymentSpecificationsRequest(ctx, data.DeploymentSpecifications, diag)
+
+	if data.AutoInstanceType.ValueBool() && !diag.HasError() {
+		resolveInstanceTypes(ctx, r.client, deploymentSpecifications, deployed, diag)
+	}
+	return deploymentSpecifications
+}
+
+// planDeploymentInstanceTypes plans the instance types resolved by auto_instance_type on a prior apply.
+func planDeploymentInstanceTypes(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
 		return
 	}
 
-	if len(plan.DeploymentSpecifications.Elements()) == 0 {
-		_, err := r.client.DeleteNvidiaCloudFunctionDeployment(ctx, state.Id.ValueString(), state.VersionID.ValueString(), plan.GracefulDeletion.ValueBool())
-		// The case we still save state, since the deployment is disabled and user can delete the version manually.
-		if err != nil {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("Failed to delete Cloud Function Deployment %s", plan.VersionID.ValueString()),
-				err.Error(),
-			)
-		}
-		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &plan, function, nil, &authorizedAccounts)
-	} else {
-		deployment := r.updateDeployment(ctx, plan, state, &resp.Diagnostics)
+	var autoInstanceType types.Bool
+	var config, plan, state types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("auto_instance_type"), &autoInstanceType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deployment_specifications"), &config)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("deployment_specifications"), &plan)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deployment_specifications"), &state)...)
 
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &resp.Diagnostics, &plan, function, &deployment, &authorizedAccounts)
+	if resp.Diagnostics.HasError() || !autoInstanceType.ValueBool() {
+		return
 	}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	plan = planInstanceTypes(ctx, config, plan, state, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deployment_specifications"), plan)...)
 }
 
-func (r *NvidiaCloudFunctionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data NvidiaCloudFunctionResourceModel
+// planInstanceTypes fills the unknown instance types of the planned specifications leaving it unset with the one
+// deployed for the same GPU, which resolveInstanceTypes keeps on apply. Set elements have no stable match with the
+// prior state, so the instance type can't be carried over by a plan modifier of the attribute. A GPU without a
+// deployed instance type stays unknown until it's resolved on apply.
+func planInstanceTypes(ctx context.Context, config types.Set, plan types.Set, state types.Set, diag *diag.Diagnostics) types.Set {
+	if config.IsNull() || config.IsUnknown() || plan.IsNull() || plan.IsUnknown() || state.IsNull() || state.IsUnknown() {
+		return plan
+	}
 
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	configSpecs := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(config.Elements()))
+	planSpecs := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(plan.Elements()))
+	stateSpecs := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(state.Elements()))
+	diag.Append(config.ElementsAs(ctx, &configSpecs, false)...)
+	diag.Append(plan.ElementsAs(ctx, &planSpecs, false)...)
+	diag.Append(state.ElementsAs(ctx, &stateSpecs, false)...)
 
-	if data.GracefulDeletion.ValueBool() {
-		_, err := r.client.DeleteNvidiaCloudFunctionDeployment(ctx, data.Id.ValueString(), data.VersionID.ValueString(), data.GracefulDeletion.ValueBool())
-		// The case we still save state, since the deployment is disabled and user can delete the version manually.
-		if err != nil {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("Failed to delete Cloud Function Deployment %s", data.VersionID.ValueString()),
-				err.Error(),
-			)
+	if diag.HasError() {
+		return plan
+	}
+
+	unsetGpus := make(map[string]bool)
+	for _, v := range configSpecs {
+		if v.InstanceType.IsNull() {
+			unsetGpus[v.GpuType.ValueString()] = true
 		}
 	}
 
-	err := r.client.DeleteNvidiaCloudFunctionVersion(ctx, data.Id.ValueString(), data.VersionID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Failed to delete Cloud Function version %s", data.VersionID.ValueString()),
-			err.Error(),
-		)
+	deployedInstanceTypes := make(map[string]string)
+	for _, v := range stateSpecs {
+		if v.InstanceType.ValueString() != "" {
+			deployedInstanceTypes[v.GpuType.ValueString()] = v.InstanceType.ValueString()
+		}
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	for i, v := range planSpecs {
+		instanceType, ok := deployedInstanceTypes[v.GpuType.ValueString()]
+		if v.InstanceType.IsUnknown() && unsetGpus[v.GpuType.ValueString()] && ok {
+			planSpecs[i].InstanceType = types.StringValue(instanceType)
+		}
 	}
-}
 
-func (r *NvidiaCloudFunctionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, ",")
+	planned, diags := types.SetValueFrom(ctx, plan.ElementType(ctx), planSpecs)
+	diag.Append(diags...)
+	return planned
+}
 
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: function_id,version_id. Got: %q", req.ID),
-		)
+// resolveInstanceTypes fills the instance type of the specifications leaving it unset. The instance type already
+// deployed for the same GPU is kept, so updates don't move instances, otherwise the most cost-effective one is picked.
+func resolveInstanceTypes(
+	ctx context.Context,
+	client *utils.NVCFClient,
+	specs []utils.NvidiaCloudFunctionDeploymentSpecification,
+	deployed types.Set,
+	diag *diag.Diagnostics,
+) {
+	deployedInstanceTypes := make(map[string]string)
+	if !deployed.IsNull() && !deployed.IsUnknown() {
+		deployedSpecs := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(deployed.Elements()))
+		diag.Append(deployed.ElementsAs(ctx, &deployedSpecs, false)...)
+		for _, v := range deployedSpecs {
+			deployedInstanceTypes[v.GpuType.ValueString()] = v.InstanceType.ValueString()
+		}
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	var clusterGroups []utils.NvidiaCloudFunctionClusterGroup
+	for i, spec := range specs {
+		if spec.InstanceType != "" {
+			continue
+		}
+
+		if instanceType, ok := deployedInstanceTypes[spec.Gpu]; ok && instanceType != "" {
+			specs[i].InstanceType = instanceType
+			continue
+		}
+
+		if clusterGroups == nil {
+			listClusterGroupsResponse, err := client.ListClusterGroups(ctx)
+			if err != nil {
+				diag.AddError("Failed to list Cloud Function cluster groups", err.Error())
+				return
+			}
+			clusterGroups = listClusterGroupsResponse.ClusterGroups
+		}
+
+		instanceType, err := utils.SelectInstanceType(clusterGroups, spec.Gpu, spec.Backend, spec.Clusters)
+		if err != nil {
+			diag.AddAttributeError(
+				path.Root("deployment_specifications"),
+				"Failed to resolve Instance Type",
+				err.Error(),
+			)
+			return
+		}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version_id"), idParts[1])...)
+		tflog.Info(ctx, "resolved instance type", map[string]interface{}{
+			"gpu_type":      spec.Gpu,
+			"instance_type": instanceType,
+		})
+		specs[i].InstanceType = instanceType
+	}
 }
 
-func (r *NvidiaCloudFunctionResource) prepareDeploymentSpecifications(
+// deploymentSpecificationsRequest converts the planned deployment specifications to their API request,
+// nil when none are set.
+func deploymentSpecificationsRequest(
 	ctx context.Context,
-	data NvidiaCloudFunctionResourceModel,
+	specs types.Set,
 	diag *diag.Diagnostics,
 ) []utils.NvidiaCloudFunctionDeploymentSpecification {
-	if data.DeploymentSpecifications.IsNull() || len(data.DeploymentSpecifications.Elements()) == 0 {
+	if specs.IsNull() || len(specs.Elements()) == 0 {
 		return nil
 	}
 
-	deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(data.DeploymentSpecifications.Elements()))
-	diag.Append(data.DeploymentSpecifications.ElementsAs(ctx, &deploymentSpecifications, false)...)
+	deploymentSpecifications := make([]NvidiaCloudFunctionResourceDeploymentSpecificationModel, 0, len(specs.Elements()))
+	diag.Append(specs.ElementsAs(ctx, &deploymentSpecifications, false)...)
 
 	if diag.HasError() {
 		return nil
@@ -1262,42 +2756,124 @@ func (r *NvidiaCloudFunctionResource) prepareDeploymentSpecifications(
 func (r *NvidiaCloudFunctionResource) createDeployment(ctx context.Context, data NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics, function utils.NvidiaCloudFunctionInfo) utils.NvidiaCloudFunctionDeployment {
 	var functionDeployment utils.NvidiaCloudFunctionDeployment
 
-	deploymentSpecificationsOption := r.prepareDeploymentSpecifications(ctx, data, diag)
+	deploymentSpecificationsOption := r.prepareDeploymentSpecifications(ctx, data, types.SetNull(deploymentSpecificationsSchema().NestedObject.Type()), diag)
 	if diag.HasError() || deploymentSpecificationsOption == nil {
 		return functionDeployment
 	}
 
-	createNvidiaCloudFunctionDeploymentResponse, err := r.client.CreateNvidiaCloudFunctionDeployment(
-		ctx, function.ID, function.VersionID,
-		utils.CreateNvidiaCloudFunctionDeploymentRequest{
-			DeploymentSpecifications: deploymentSpecificationsOption,
-		},
-	)
+	var createNvidiaCloudFunctionDeploymentResponse *utils.CreateNvidiaCloudFunctionDeploymentResponse
+	err := retryTransientCreateError(ctx, r.client.WaitClock(), data.CreateRetry.ValueBool(), func() error {
+		var err error
+		createNvidiaCloudFunctionDeploymentResponse, err = r.client.CreateNvidiaCloudFunctionDeployment(
+			ctx, function.ID, function.VersionID,
+			utils.CreateNvidiaCloudFunctionDeploymentRequest{
+				DeploymentSpecifications: deploymentSpecificationsOption,
+			},
+		)
+		return err
+	})
 
 	if err != nil {
-		diag.AddError(
-			"Failed to create Cloud Function Deployment",
-			err.Error(),
-		)
+		addDeploymentError(diag, "Failed to create Cloud Function Deployment", err)
+		return functionDeployment
+	}
+
+	functionDeployment = createNvidiaCloudFunctionDeploymentResponse.Deployment
+	if !data.WaitForActive.ValueBool() {
+		tflog.Info(ctx, "skip waiting for the function deployment to become active")
 		return functionDeployment
 	}
 
-	err = r.client.WaitingDeploymentCompleted(ctx, function.ID, function.VersionID)
+	err = waitWithinMaxDeploymentWait(ctx, data.MaxDeploymentWait, func(ctx context.Context) error {
+		return r.client.WaitingDeploymentCompleted(ctx, function.ID, function.VersionID)
+	})
 	if err != nil {
 		diag.AddError(
 			"Failed to create Cloud Function Deployment",
 			err.Error(),
 		)
+		return utils.NvidiaCloudFunctionDeployment{}
+	}
+
+	// The create response still carries the initial status, waiting only succeeds once ACTIVE,
+	// or INACTIVE for a deployment scaling to zero.
+	if !utils.ScalesToZero(functionDeployment.DeploymentSpecifications) {
+		functionDeployment.FunctionStatus = "ACTIVE"
 		return functionDeployment
 	}
 
-	return createNvidiaCloudFunctionDeploymentResponse.Deployment
+	readNvidiaCloudFunctionDeploymentResponse, err := r.client.ReadNvidiaCloudFunctionDeployment(ctx, function.ID, function.VersionID)
+	if err != nil {
+		diag.AddError("Failed to read Cloud Function Deployment", err.Error())
+		return utils.NvidiaCloudFunctionDeployment{}
+	}
+	return readNvidiaCloudFunctionDeploymentResponse.Deployment
+}
+
+const createRetryBaseDelay = 15 * time.Second
+const createRetryMaxDelay = 2 * time.Minute
+
+// retryTransientCreateError calls fn again with an exponential backoff while it fails for lack of GPU quota,
+// which is often only a brief contention. It gives up once ctx, bound to the create timeout, is done.
+// The backoff waits on clock, the clock of the client.
+func retryTransientCreateError(ctx context.Context, clock utils.Clock, enabled bool, fn func() error) error {
+	delay := createRetryBaseDelay
+	for {
+		err := fn()
+		if err == nil || !enabled || !errors.Is(err, utils.ErrQuotaExceeded) {
+			return err
+		}
+
+		tflog.Warn(ctx, "transient deployment failure, retrying", map[string]interface{}{
+			"error": err.Error(),
+			"delay": delay.String(),
+		})
+		select {
+		case <-ctx.Done():
+			return err
+		case <-clock.After(delay):
+		}
+
+		delay = min(delay*2, createRetryMaxDelay)
+	}
+}
+
+// waitWithinMaxDeploymentWait calls wait with ctx further bound by max_deployment_wait, when set.
+// The shorter of it and the resource timeout wins.
+func waitWithinMaxDeploymentWait(ctx context.Context, maxDeploymentWait types.String, wait func(ctx context.Context) error) error {
+	d, err := time.ParseDuration(maxDeploymentWait.ValueString())
+	if maxDeploymentWait.IsNull() || maxDeploymentWait.IsUnknown() || err != nil || d <= 0 {
+		return wait(ctx)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	err = wait(waitCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("deployment not completed within max_deployment_wait %s: %w", maxDeploymentWait.ValueString(), err)
+	}
+	return err
+}
+
+// addDeploymentError adds a deployment request failure, with guidance when it failed for lack of GPU quota.
+func addDeploymentError(diag *diag.Diagnostics, summary string, err error) {
+	if errors.Is(err, utils.ErrQuotaExceeded) {
+		diag.AddError(
+			"Insufficient GPU Quota",
+			fmt.Sprintf("%s: %s\n\nAll the GPU instances allocated to the org are in use. "+
+				"Lower min_instances, pick another gpu_type or instance_type, release unused deployments, "+
+				"or contact your support team for a larger allocation, then apply again.", summary, err.Error()),
+		)
+		return
+	}
+	diag.AddError(summary, err.Error())
 }
 
 func (r *NvidiaCloudFunctionResource) updateDeployment(ctx context.Context, plan NvidiaCloudFunctionResourceModel, state NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) utils.NvidiaCloudFunctionDeployment {
 	var functionDeployment utils.NvidiaCloudFunctionDeployment
 
-	planSpecs := r.prepareDeploymentSpecifications(ctx, plan, diag)
+	planSpecs := r.prepareDeploymentSpecifications(ctx, plan, state.DeploymentSpecifications, diag)
 	if diag.HasError() || planSpecs == nil {
 		return functionDeployment
 	}
@@ -1321,6 +2897,31 @@ func (r *NvidiaCloudFunctionResource) updateDeployment(ctx context.Context, plan
 		return functionDeployment
 	}
 
+	// Request concurrency can only be changed through the deployment update API,
+	// which also carries the instance counts of every specification.
+	if deploymentSpecsConcurrencyChanged(stateSpecs, planSpecs) {
+		if currentDeployment == nil {
+			var err error
+			currentDeployment, err = r.client.ReadNvidiaCloudFunctionDeployment(
+				ctx, state.Id.ValueString(), state.VersionID.ValueString())
+			if err != nil {
+				diag.AddError("Failed to read deployment for update", err.Error())
+				return functionDeployment
+			}
+		}
+		preserveDeploymentSpecExtras(planSpecs, currentDeployment.Deployment.DeploymentSpecifications)
+
+		_, err := r.client.UpdateNvidiaCloudFunctionDeployment(ctx, state.Id.ValueString(), state.VersionID.ValueString(),
+			utils.UpdateNvidiaCloudFunctionDeploymentRequest{
+				DeploymentSpecifications: planSpecs,
+			})
+		if err != nil {
+			addDeploymentError(diag, "Failed to update Cloud Function Deployment", err)
+			return functionDeployment
+		}
+		return r.waitForUpdatedDeployment(ctx, plan, state, diag)
+	}
+
 	gpuSpecIDMap := buildGpuSpecIDMap(stateSpecs, currentDeployment)
 
 	for _, planSpec := range planSpecs {
@@ -1338,15 +2939,25 @@ func (r *NvidiaCloudFunctionResource) updateDeployment(ctx context.Context, plan
 				MinInstances: planSpec.MinInstances,
 			})
 		if err != nil {
-			diag.AddError("Failed to update GPU specification", err.Error())
+			addDeploymentError(diag, "Failed to update GPU specification", err)
 			return functionDeployment
 		}
 	}
 
-	err := r.client.WaitingDeploymentCompleted(ctx, state.Id.ValueString(), state.VersionID.ValueString())
-	if err != nil {
-		diag.AddError("Failed to update Cloud Function Deployment", err.Error())
-		return functionDeployment
+	return r.waitForUpdatedDeployment(ctx, plan, state, diag)
+}
+
+func (r *NvidiaCloudFunctionResource) waitForUpdatedDeployment(ctx context.Context, plan NvidiaCloudFunctionResourceModel, state NvidiaCloudFunctionResourceModel, diag *diag.Diagnostics) utils.NvidiaCloudFunctionDeployment {
+	var functionDeployment utils.NvidiaCloudFunctionDeployment
+
+	if plan.WaitForActive.ValueBool() {
+		err := waitWithinMaxDeploymentWait(ctx, plan.MaxDeploymentWait, func(ctx context.Context) error {
+			return r.client.WaitingDeploymentCompleted(ctx, state.Id.ValueString(), state.VersionID.ValueString())
+		})
+		if err != nil {
+			diag.AddError("Failed to update Cloud Function Deployment", err.Error())
+			return functionDeployment
+		}
 	}
 
 	resp, err := r.client.ReadNvidiaCloudFunctionDeployment(
@@ -1362,6 +2973,42 @@ func deploymentSpecKey(spec utils.NvidiaCloudFunctionDeploymentSpecification) st
 	return spec.Gpu + "|" + spec.InstanceType
 }
 
+// deploymentSpecsConcurrencyChanged reports whether any planned specification changes
+// max_request_concurrency compared with the state specification of the same gpu and instance type.
+func deploymentSpecsConcurrencyChanged(
+	stateSpecs []NvidiaCloudFunctionResourceDeploymentSpecificationModel,
+	planSpecs []utils.NvidiaCloudFunctionDeploymentSpecification,
+) bool {
+	stateConcurrency := make(map[string]int64)
+	for _, s := range stateSpecs {
+		stateConcurrency[s.GpuType.ValueString()+"|"+s.InstanceType.ValueString()] = s.MaxRequestConcurrency.ValueInt64()
+	}
+
+	for _, planSpec := range planSpecs {
+		if concurrency, ok := stateConcurrency[deploymentSpecKey(planSpec)]; ok && concurrency != int64(planSpec.MaxRequestConcurrency) {
+			return true
+		}
+	}
+	return false
+}
+
+// preserveDeploymentSpecExtras copies the fields unknown to the provider from the deployed specification
+// of the same gpu and instance type, as the specifications built from the configuration never carry them.
+func preserveDeploymentSpecExtras(planSpecs []utils.NvidiaCloudFunctionDeploymentSpecification, deployedSpecs []utils.NvidiaCloudFunctionDeploymentSpecification) {
+	extras := make(map[string]map[string]json.RawMessage)
+	for _, s := range deployedSpecs {
+		if len(s.Extras) > 0 {
+			extras[deploymentSpecKey(s)] = s.Extras
+		}
+	}
+
+	for i := range planSpecs {
+		if planSpecs[i].Extras == nil {
+			planSpecs[i].Extras = extras[deploymentSpecKey(planSpecs[i])]
+		}
+	}
+}
+
 func buildGpuSpecIDMap(
 	stateSpecs []NvidiaCloudFunctionResourceDeploymentSpecificationModel,
 	fallbackDeployment *utils.ReadNvidiaCloudFunctionDeploymentResponse,
@@ -147,6 +147,76 @@ func TestAccCloudFunctionResource_CreateHelmBasedFunctionFail(t *testing.T) {
 	})
 }
 
+func TestAccCloudFunctionResource_CreateHelmBasedFunctionWithoutServiceNameFail(t *testing.T) {
+	var functionName = testutils.TestCommonPrefix + "helm-based-function-without-service-name-fail"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+						resource "ngc_cloud_function" "%s" {
+						    function_name           = "%s"
+							helm_chart              = "%s"
+							inference_port          = %d
+							inference_url           = "%s"
+							api_body_format         = "%s"
+						}
+						`,
+					functionName,
+					functionName,
+					testutils.TestHelmUri,
+					testutils.TestHelmServicePort,
+					testutils.TestHelmInferenceUrl,
+					testutils.TestHelmAPIFormat,
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Missing Helm Chart Service Name"),
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionResource_CreateHelmBasedFunctionWithContainerEnvironmentFail(t *testing.T) {
+	var functionName = testutils.TestCommonPrefix + "helm-based-function-with-container-environment-fail"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+						resource "ngc_cloud_function" "%s" {
+						    function_name           = "%s"
+							helm_chart              = "%s"
+							helm_chart_service_name = "%s"
+							inference_port          = %d
+							inference_url           = "%s"
+							api_body_format         = "%s"
+							container_environment = [
+								{
+									key   = "mock_key"
+									value = "mock_value"
+								}
+							]
+						}
+						`,
+					functionName,
+					functionName,
+					testutils.TestHelmUri,
+					testutils.TestHelmServiceName,
+					testutils.TestHelmServicePort,
+					testutils.TestHelmInferenceUrl,
+					testutils.TestHelmAPIFormat,
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unsupported Helm Chart Setting"),
+			},
+		},
+	})
+}
+
 func TestAccCloudFunctionResource_CreateHelmBasedFunctionVersionDeploySuccess(t *testing.T) {
 	var functionName = testutils.TestCommonPrefix + "helm-based-function-version"
 	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", functionName)
@@ -358,6 +428,7 @@ func TestAccCloudFunctionResource_CreateHelmBasedFunctionVersionDeploySuccess(t
 func TestAccCloudFunctionResource_CreateContainerBasedFunctionVersionDeploySuccess(t *testing.T) {
 	var functionName = testutils.TestCommonPrefix + "container-based-function-version"
 	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", functionName)
+	var versionID string
 
 	functionInfo := testutils.CreateContainerFunction(t)
 	defer testutils.DeleteFunction(t, functionInfo.Function.ID, functionInfo.Function.VersionID)
@@ -413,7 +484,10 @@ func TestAccCloudFunctionResource_CreateContainerBasedFunctionVersionDeploySucce
 					testutils.TestGpuType,
 				),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttrSet(testCloudFunctionResourceFullPath, "version_id"),
+					resource.TestCheckResourceAttrWith(testCloudFunctionResourceFullPath, "version_id", func(value string) error {
+						versionID = value
+						return nil
+					}),
 
 					resource.TestCheckNoResourceAttr(testCloudFunctionResourceFullPath, "helm_chart"),
 					resource.TestCheckNoResourceAttr(testCloudFunctionResourceFullPath, "helm_chart_service_name"),
@@ -552,6 +626,74 @@ func TestAccCloudFunctionResource_CreateContainerBasedFunctionVersionDeploySucce
 					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "authorized_parties.#", "2"),
 				),
 			},
+			// Verify Function Update (only max_request_concurrency changed) keeps the same version
+			{
+				Config: fmt.Sprintf(`
+						resource "ngc_cloud_function" "%s" {
+							function_name           = "%s"
+						    function_id             = "%s"
+							container_image         = "%s"
+							inference_port          = %d
+							inference_url           = "%s"
+							health                    = {
+								uri                  = "%s"
+								port                 = %d
+								expected_status_code = 200
+								timeout              = "PT10S"
+								protocol             = "HTTP"
+							}
+							api_body_format         = "%s"
+							deployment_specifications = [
+								{
+									clusters                = ["%s", "%s"]
+									regions                 = ["%s", "%s"]
+									instance_type           = "%s"
+									gpu_type                = "%s"
+									max_instances           = 2
+									min_instances           = 1
+									max_request_concurrency = 2
+								}
+							]
+							authorized_parties = [
+								{
+									nca_id = "%s"
+								},
+								{
+									nca_id = "%s"
+								}
+							]
+						}
+						`,
+					functionName,
+					functionName,
+					functionInfo.Function.ID,
+					testutils.TestContainerUri,
+					testutils.TestContainerPort,
+					testutils.TestContainerInferenceUrl,
+					testutils.TestContainerHealthUri,
+					testutils.TestContainerPort,
+					testutils.TestContainerAPIFormat,
+					testutils.TestClusters[0],
+					testutils.TestClusters[1],
+					testutils.TestRegions[0],
+					testutils.TestRegions[1],
+					testutils.TestInstanceType,
+					testutils.TestGpuType,
+					testutils.TestAuthorizedParty1,
+					testutils.TestAuthorizedParty2,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith(testCloudFunctionResourceFullPath, "version_id", func(value string) error {
+						if value != versionID {
+							return fmt.Errorf("expected version_id %s to be kept, got %s", versionID, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.#", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.0.max_instances", "2"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.0.max_request_concurrency", "2"),
+				),
+			},
 			// Verify Function Import
 			{
 				ResourceName:      testCloudFunctionResourceFullPath,
@@ -637,6 +779,8 @@ func TestAccCloudFunctionResource_CreateFunctionWithoutDeploymentSuccess(t *test
 					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "models.0.name", testutils.TestModel1Name),
 					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "models.0.version", testutils.TestModel1Version),
 					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "models.0.uri", testutils.TestModel1FullyQualifiedUri),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "models_count", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "resources_count", "0"),
 				),
 			},
 			// Verify Function Update again won't change anything
@@ -694,6 +838,144 @@ func TestAccCloudFunctionResource_CreateFunctionWithoutDeploymentSuccess(t *test
 	})
 }
 
+func TestAccCloudFunctionResource_FunctionDeletedExternally(t *testing.T) {
+	var functionName = testutils.TestCommonPrefix + "function-deleted-externally"
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", functionName)
+	var functionID, versionID string
+
+	config := fmt.Sprintf(`
+			resource "ngc_cloud_function" "%s" {
+				function_name           = "%s"
+				container_image         = "%s"
+				inference_port          = %d
+				inference_url           = "%s"
+				health_uri              = "%s"
+				api_body_format         = "%s"
+			}
+			`,
+		functionName,
+		functionName,
+		testutils.TestContainerUri,
+		testutils.TestContainerPort,
+		testutils.TestContainerInferenceUrl,
+		testutils.TestContainerHealthUri,
+		testutils.TestContainerAPIFormat,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith(testCloudFunctionResourceFullPath, "id", func(value string) error {
+						functionID = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith(testCloudFunctionResourceFullPath, "version_id", func(value string) error {
+						versionID = value
+						return nil
+					}),
+				),
+			},
+			// Deleting the only version removes the whole function, the refresh should drop it from state.
+			{
+				PreConfig: func() {
+					testutils.DeleteFunction(t, functionID, versionID)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionResource_DeploymentDeletedExternally(t *testing.T) {
+	var functionName = testutils.TestCommonPrefix + "deployment-deleted-externally"
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", functionName)
+	var functionID, versionID string
+
+	config := fmt.Sprintf(`
+			provider "ngc" {
+				deployment_drift_detection = true
+			}
+
+			resource "ngc_cloud_function" "%s" {
+				function_name           = "%s"
+				container_image         = "%s"
+				inference_port          = %d
+				inference_url           = "%s"
+				health_uri              = "%s"
+				api_body_format         = "%s"
+				deployment_specifications = [
+					{
+						instance_type           = "%s"
+						gpu_type                = "%s"
+						max_instances           = 1
+						min_instances           = 1
+						max_request_concurrency = 1
+					}
+				]
+			}
+			`,
+		functionName,
+		functionName,
+		testutils.TestContainerUri,
+		testutils.TestContainerPort,
+		testutils.TestContainerInferenceUrl,
+		testutils.TestContainerHealthUri,
+		testutils.TestContainerAPIFormat,
+		testutils.TestInstanceType,
+		testutils.TestGpuType,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith(testCloudFunctionResourceFullPath, "id", func(value string) error {
+						functionID = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith(testCloudFunctionResourceFullPath, "version_id", func(value string) error {
+						versionID = value
+						return nil
+					}),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.#", "1"),
+				),
+			},
+			// The version is kept, the refresh should report its deployment as gone.
+			{
+				PreConfig: func() {
+					_, err := testutils.TestNVCFClient.DeleteNvidiaCloudFunctionDeployment(testutils.Ctx, functionID, versionID, false)
+					if err != nil {
+						t.Fatalf("Unable to delete deployment: %s", err.Error())
+					}
+					if err := testutils.TestNVCFClient.WaitingDeploymentDeleted(testutils.Ctx, functionID, versionID); err != nil {
+						t.Fatalf("Deployment not deleted: %s", err.Error())
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying deploys the version again.
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_specifications.#", "1"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "deployment_status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCloudFunctionResource_CreateFunctionWithTelemetriesWithoutDeploymentSuccess(t *testing.T) {
 	var functionName = testutils.TestCommonPrefix + "function-with-telemetries-without-deployment"
 	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", functionName)
@@ -1173,3 +1455,68 @@ func TestAccCloudFunctionResource_CreateFunctionWithLegacyArtifactUrlsFormatSucc
 		},
 	})
 }
+
+func TestAccCloudFunctionResource_ForceNewVersion(t *testing.T) {
+	var functionName = testutils.TestCommonPrefix + "function-force-new-version"
+	var testCloudFunctionResourceFullPath = fmt.Sprintf("ngc_cloud_function.%s", functionName)
+	var versionID string
+
+	functionInfo := testutils.CreateContainerFunction(t)
+	defer testutils.DeleteFunction(t, functionInfo.Function.ID, functionInfo.Function.VersionID)
+
+	config := func(forceNewVersion string) string {
+		return fmt.Sprintf(`
+			resource "ngc_cloud_function" "%s" {
+				function_name           = "%s"
+				function_id             = "%s"
+				container_image         = "%s"
+				inference_port          = %d
+				inference_url           = "%s"
+				health_uri              = "%s"
+				api_body_format         = "%s"
+				force_new_version       = "%s"
+			}
+			`,
+			functionName,
+			functionName,
+			functionInfo.Function.ID,
+			testutils.TestContainerUri,
+			testutils.TestContainerPort,
+			testutils.TestContainerInferenceUrl,
+			testutils.TestContainerHealthUri,
+			testutils.TestContainerAPIFormat,
+			forceNewVersion,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "force_new_version", "1"),
+					resource.TestCheckResourceAttrWith(testCloudFunctionResourceFullPath, "version_id", func(value string) error {
+						versionID = value
+						return nil
+					}),
+				),
+			},
+			// Bumping the trigger creates a new version of the same function.
+			{
+				Config: config("2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "force_new_version", "2"),
+					resource.TestCheckResourceAttr(testCloudFunctionResourceFullPath, "id", functionInfo.Function.ID),
+					resource.TestCheckResourceAttrWith(testCloudFunctionResourceFullPath, "version_id", func(value string) error {
+						if value == versionID {
+							return fmt.Errorf("expected a new version, got the previous version %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
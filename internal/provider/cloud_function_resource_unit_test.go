@@ -0,0 +1,2190 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestManagedTags(t *testing.T) {
+	t.Parallel()
+
+	assert.ElementsMatch(t, []string{"mock1"}, managedTags([]string{"mock1", "nvcf-auto"}, []string{"mock1", "mock2"}))
+	assert.Empty(t, managedTags([]string{"nvcf-auto"}, nil))
+	assert.ElementsMatch(t, []string{"nvcf-auto"}, unmanagedTags([]string{"mock1", "nvcf-auto"}, []string{"mock1"}))
+	assert.Empty(t, unmanagedTags(nil, []string{"mock1"}))
+}
+
+func TestUpdateNvidiaCloudFunctionResourceModel_IgnoresServerTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+
+	tests := []struct {
+		name       string
+		priorTags  types.Set
+		serverTags []string
+		wantTags   []string
+	}{
+		{
+			name:       "TagsNotConfigured",
+			priorTags:  types.SetValueMust(types.StringType, nil),
+			serverTags: []string{"nvcf-auto"},
+			wantTags:   []string{},
+		},
+		{
+			name:       "TagsConfigured",
+			priorTags:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("mock1")}),
+			serverTags: []string{"mock1", "nvcf-auto"},
+			wantTags:   []string{"mock1"},
+		},
+		{
+			name:       "ImportTracksAllTags",
+			priorTags:  types.SetNull(types.StringType),
+			serverTags: []string{"mock1", "nvcf-auto"},
+			wantTags:   []string{"mock1", "nvcf-auto"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			data := NvidiaCloudFunctionResourceModel{Tags: tt.priorTags}
+
+			r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{Tags: tt.serverTags}, nil, nil)
+
+			assert.False(t, diags.HasError(), diags)
+			var gotTags []string
+			data.Tags.ElementsAs(ctx, &gotTags, false)
+			assert.ElementsMatch(t, tt.wantTags, gotTags)
+		})
+	}
+}
+
+func TestUpdateNvidiaCloudFunctionResourceModel_NcaIDStable(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+
+	var diags diag.Diagnostics
+	data := NvidiaCloudFunctionResourceModel{NcaId: types.StringUnknown()}
+
+	// Creating the version, whose response lacks the NCA ID, takes it from the deployment.
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{}, &utils.NvidiaCloudFunctionDeployment{NcaID: "mock-nca-id"}, nil)
+	assert.Equal(t, types.StringValue("mock-nca-id"), data.NcaId)
+
+	reads := []struct {
+		functionInfo       *utils.NvidiaCloudFunctionInfo
+		functionDeployment *utils.NvidiaCloudFunctionDeployment
+	}{
+		{&utils.NvidiaCloudFunctionInfo{NcaID: "mock-nca-id"}, &utils.NvidiaCloudFunctionDeployment{NcaID: "mock-nca-id"}},
+		{&utils.NvidiaCloudFunctionInfo{NcaID: "mock-nca-id"}, nil},
+		{&utils.NvidiaCloudFunctionInfo{}, &utils.NvidiaCloudFunctionDeployment{}},
+		{&utils.NvidiaCloudFunctionInfo{}, nil},
+	}
+	for _, read := range reads {
+		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, read.functionInfo, read.functionDeployment, nil)
+		assert.Equal(t, types.StringValue("mock-nca-id"), data.NcaId)
+	}
+	assert.False(t, diags.HasError(), diags)
+
+	// The version is the source of truth.
+	assert.Equal(t, "mock-nca-id", functionNcaID(&utils.NvidiaCloudFunctionInfo{NcaID: "mock-nca-id"}, &utils.NvidiaCloudFunctionDeployment{NcaID: "other-nca-id"}))
+
+	// Nothing to tell after create, the computed value can't stay unknown.
+	data = NvidiaCloudFunctionResourceModel{NcaId: types.StringUnknown()}
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{}, nil, nil)
+	assert.True(t, data.NcaId.IsNull())
+}
+
+func TestUpdateNvidiaCloudFunctionResourceModel_HealthTakesPrecedenceOverHealthUri(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+	functionInfo := &utils.NvidiaCloudFunctionInfo{
+		HealthURI: "/v2/health/ready",
+		Health:    &utils.NvidiaCloudFunctionHealth{Protocol: "HTTP", URI: "/health", Port: 8000, Timeout: "PT10S", ExpectedStatusCode: 200},
+	}
+
+	var diags diag.Diagnostics
+	data := NvidiaCloudFunctionResourceModel{HealthUri: types.StringUnknown(), Tags: types.SetNull(types.StringType)}
+
+	// Create
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, functionInfo, nil, nil)
+	assert.Equal(t, types.StringValue("/health"), data.HealthUri)
+
+	// Subsequent reads don't flip to the echoed health_uri.
+	for i := 0; i < 2; i++ {
+		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, functionInfo, nil, nil)
+		assert.Equal(t, types.StringValue("/health"), data.HealthUri)
+	}
+
+	// A configured legacy health_uri is kept when NVCF derives the health block from it.
+	data.HealthUri = types.StringValue("/v2/health/ready")
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, functionInfo, nil, nil)
+	assert.Equal(t, types.StringValue("/v2/health/ready"), data.HealthUri)
+
+	// Functions without a health block keep using the echoed health_uri.
+	data.HealthUri = types.StringUnknown()
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{HealthURI: "/v2/health/ready"}, nil, nil)
+	assert.Equal(t, types.StringValue("/v2/health/ready"), data.HealthUri)
+
+	assert.False(t, diags.HasError(), diags)
+}
+
+// contextAwareRoundTripper mimics http.Transport by failing requests whose context is done.
+type contextAwareRoundTripper struct {
+	requests     []*http.Request
+	responseCode int
+}
+
+func (rt *contextAwareRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	rt.requests = append(rt.requests, req)
+	return &http.Response{
+		StatusCode: rt.responseCode,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+func TestDeleteFailedDeploymentVersion_CreateContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	rt := &contextAwareRoundTripper{responseCode: http.StatusNoContent}
+	r := &NvidiaCloudFunctionResource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var diags diag.Diagnostics
+	r.deleteFailedDeploymentVersion(ctx, false, "mock-function-id", "mock-version-id", &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Len(t, rt.requests, 1)
+	assert.Equal(t, http.MethodDelete, rt.requests[0].Method)
+	assert.Equal(t, "/v2/orgs/mock-org/nvcf/functions/mock-function-id/versions/mock-version-id", rt.requests[0].URL.Path)
+}
+
+func TestDeleteFailedDeploymentVersion_KeepFailedResource(t *testing.T) {
+	t.Parallel()
+
+	rt := &contextAwareRoundTripper{responseCode: http.StatusNoContent}
+	r := &NvidiaCloudFunctionResource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	var diags diag.Diagnostics
+	r.deleteFailedDeploymentVersion(context.Background(), true, "mock-function-id", "mock-version-id", &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Empty(t, rt.requests)
+}
+
+func TestValidatePredictV2Endpoints(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	healthModel := &NvidiaCloudFunctionResourceHealthModel{}
+	httpHealth := func(uri string) types.Object {
+		return types.ObjectValueMust(healthModel.attrTypes(), map[string]attr.Value{
+			"protocol":             types.StringValue("HTTP"),
+			"uri":                  types.StringValue(uri),
+			"port":                 types.Int64Value(8000),
+			"timeout":              types.StringValue("PT10S"),
+			"expected_status_code": types.Int64Value(200),
+		})
+	}
+
+	tests := []struct {
+		name        string
+		data        NvidiaCloudFunctionResourceModel
+		wantErrPath []string
+	}{
+		{
+			name: "Defaults",
+			data: NvidiaCloudFunctionResourceModel{
+				InferenceUrl: types.StringValue("/v2/models/mock-model/infer"),
+				HealthUri:    types.StringNull(),
+				Health:       types.ObjectNull(healthModel.attrTypes()),
+			},
+		},
+		{
+			name: "VersionedModel",
+			data: NvidiaCloudFunctionResourceModel{
+				InferenceUrl: types.StringValue("/v2/models/mock-model/versions/1/infer"),
+				HealthUri:    types.StringValue("/v2/health/live"),
+				Health:       types.ObjectNull(healthModel.attrTypes()),
+			},
+		},
+		{
+			name: "IncompatibleOverrides",
+			data: NvidiaCloudFunctionResourceModel{
+				InferenceUrl: types.StringValue("/echo"),
+				HealthUri:    types.StringValue("/health"),
+				Health:       types.ObjectNull(healthModel.attrTypes()),
+			},
+			wantErrPath: []string{"inference_url", "health_uri"},
+		},
+		{
+			name: "MissingInferenceUrl",
+			data: NvidiaCloudFunctionResourceModel{
+				InferenceUrl: types.StringNull(),
+				HealthUri:    types.StringNull(),
+				Health:       types.ObjectNull(healthModel.attrTypes()),
+			},
+			wantErrPath: []string{"inference_url"},
+		},
+		{
+			name: "IncompatibleHealthBlock",
+			data: NvidiaCloudFunctionResourceModel{
+				InferenceUrl: types.StringUnknown(),
+				HealthUri:    types.StringNull(),
+				Health:       httpHealth("/health"),
+			},
+			wantErrPath: []string{"health.uri"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validatePredictV2Endpoints(ctx, tt.data, &diags)
+
+			var gotErrPath []string
+			for _, d := range diags.Errors() {
+				gotErrPath = append(gotErrPath, d.(diag.DiagnosticWithPath).Path().String())
+			}
+			assert.Equal(t, tt.wantErrPath, gotErrPath)
+		})
+	}
+}
+
+func TestValidateHealthProtocol(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	healthModel := &NvidiaCloudFunctionResourceHealthModel{}
+	health := func(protocol string) types.Object {
+		return types.ObjectValueMust(healthModel.attrTypes(), map[string]attr.Value{
+			"protocol":             types.StringValue(protocol),
+			"uri":                  types.StringValue("/health"),
+			"port":                 types.Int64Value(8001),
+			"timeout":              types.StringValue("PT10S"),
+			"expected_status_code": types.Int64Value(0),
+		})
+	}
+
+	tests := []struct {
+		name    string
+		health  types.Object
+		wantErr bool
+	}{
+		{
+			name:   "HTTP",
+			health: health("HTTP"),
+		},
+		{
+			name:   "GRPC",
+			health: health("GRPC"),
+		},
+		{
+			name:   "NotSet",
+			health: types.ObjectNull(healthModel.attrTypes()),
+		},
+		{
+			name:    "Unsupported",
+			health:  health("gRPC"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateHealthProtocol(ctx, NvidiaCloudFunctionResourceModel{Health: tt.health}, &diags)
+
+			assert.Equal(t, tt.wantErr, diags.HasError(), diags)
+		})
+	}
+}
+
+func TestValidateContainerImageTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		image       types.String
+		check       types.String
+		wantWarning bool
+		wantErr     bool
+	}{
+		{
+			name:  "Tagged",
+			image: types.StringValue("nvcr.io/mock-org/mock-image:1.0.0"),
+			check: types.StringValue("ERROR"),
+		},
+		{
+			name:  "ExplicitLatest",
+			image: types.StringValue("nvcr.io/mock-org/mock-image:latest"),
+			check: types.StringValue("ERROR"),
+		},
+		{
+			name:  "Digest",
+			image: types.StringValue("nvcr.io/mock-org/mock-image@sha256:0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e"),
+			check: types.StringValue("ERROR"),
+		},
+		{
+			name:  "HelmChart",
+			image: types.StringNull(),
+			check: types.StringValue("ERROR"),
+		},
+		{
+			name:        "Untagged",
+			image:       types.StringValue("nvcr.io/mock-org/mock-image"),
+			check:       types.StringNull(),
+			wantWarning: true,
+		},
+		{
+			name:        "UntaggedWithRegistryPort",
+			image:       types.StringValue("registry.example.com:5000/mock-image"),
+			check:       types.StringValue("WARN"),
+			wantWarning: true,
+		},
+		{
+			name:    "UntaggedStrict",
+			image:   types.StringValue("nvcr.io/mock-org/mock-image"),
+			check:   types.StringValue("ERROR"),
+			wantErr: true,
+		},
+		{
+			name:  "UntaggedOptedOut",
+			image: types.StringValue("nvcr.io/mock-org/mock-image"),
+			check: types.StringValue("OFF"),
+		},
+		{
+			name:    "InvalidCheck",
+			image:   types.StringValue("nvcr.io/mock-org/mock-image:1.0.0"),
+			check:   types.StringValue("warn"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateContainerImageTag(NvidiaCloudFunctionResourceModel{ContainerImage: tt.image, ContainerImageTagCheck: tt.check}, &diags)
+
+			assert.Equal(t, tt.wantErr, diags.HasError(), diags)
+			assert.Equal(t, tt.wantWarning, diags.WarningsCount() > 0, diags)
+		})
+	}
+}
+
+func TestHealthProtocolRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+	healthModel := &NvidiaCloudFunctionResourceHealthModel{}
+
+	var diags diag.Diagnostics
+	plan := NvidiaCloudFunctionResourceModel{
+		Health: types.ObjectValueMust(healthModel.attrTypes(), map[string]attr.Value{
+			"protocol":             types.StringValue("GRPC"),
+			"uri":                  types.StringValue("/grpc.health.v1.Health/Check"),
+			"port":                 types.Int64Value(8001),
+			"timeout":              types.StringValue("PT10S"),
+			"expected_status_code": types.Int64Value(0),
+		}),
+	}
+	request := r.createOrUpdateRequest(ctx, plan, &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "GRPC", request.Health.Protocol)
+
+	data := NvidiaCloudFunctionResourceModel{Health: types.ObjectUnknown(healthModel.attrTypes()), HealthUri: types.StringNull()}
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{Health: request.Health}, nil, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, plan.Health.Equal(data.Health), data.Health)
+}
+
+func TestInferenceUrlDefault(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	inferenceUrl, ok := schemaResp.Schema.Attributes["inference_url"].(schema.StringAttribute)
+	assert.True(t, ok)
+	assert.True(t, inferenceUrl.Optional)
+
+	defaultResp := &defaults.StringResponse{}
+	inferenceUrl.Default.DefaultString(ctx, defaults.StringRequest{}, defaultResp)
+	assert.Equal(t, types.StringValue("/"), defaultResp.PlanValue)
+
+	// The default is echoed back on read, or omitted by the API, and stays stable either way.
+	var diags diag.Diagnostics
+	for _, echoed := range []string{"/", ""} {
+		data := NvidiaCloudFunctionResourceModel{InferenceUrl: defaultResp.PlanValue}
+		r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{InferenceURL: echoed}, nil, nil)
+
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue("/"), data.InferenceUrl)
+	}
+}
+
+func TestArtifactUriPlanModifier(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&NvidiaCloudFunctionResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	for name, artifactSchema := range map[string]schema.SetNestedAttribute{"models": modelsSchema(), "resources": resourcesSchema()} {
+		t.Run(name, func(t *testing.T) {
+			for _, tt := range []struct {
+				artifactHost string
+				uri          string
+				want         string
+			}{
+				{uri: "v2/org/mock-org/models/mock-model/1.0/files", want: "https://api.ngc.nvidia.com/v2/org/mock-org/models/mock-model/1.0/files"},
+				{artifactHost: "https://api.stg.ngc.nvidia.com", uri: "v2/org/mock-org/models/mock-model/1.0/files", want: "https://api.stg.ngc.nvidia.com/v2/org/mock-org/models/mock-model/1.0/files"},
+				{artifactHost: "https://api.stg.ngc.nvidia.com", uri: "https://api.ngc.nvidia.com/v2/org/mock-org/models/mock-model/1.0/files", want: "https://api.ngc.nvidia.com/v2/org/mock-org/models/mock-model/1.0/files"},
+			} {
+				// The host comes from the client of each resource, two providers with different endpoints don't share it.
+				r := &NvidiaCloudFunctionResource{artifactHost: tt.artifactHost}
+				plan := tfsdk.Plan{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				}
+				artifacts := types.SetValueMust(artifactSchema.NestedObject.Type(), []attr.Value{
+					types.ObjectValueMust(artifactSchema.NestedObject.Type().(types.ObjectType).AttrTypes, map[string]attr.Value{
+						"name":    types.StringValue("mock-model"),
+						"version": types.StringValue("1.0"),
+						"uri":     types.StringValue(tt.uri),
+					}),
+				})
+				var diags diag.Diagnostics
+				diags.Append(plan.SetAttribute(ctx, path.Root(name), artifacts)...)
+
+				r.planArtifactUris(ctx, &plan, path.Root(name), &diags)
+				assert.False(t, diags.HasError(), diags)
+
+				var planned []NvidiaCloudFunctionResourceModelModel
+				diags.Append(plan.GetAttribute(ctx, path.Root(name), &planned)...)
+				assert.False(t, diags.HasError(), diags)
+				assert.Len(t, planned, 1)
+				assert.Equal(t, tt.want, planned[0].Uri.ValueString())
+			}
+		})
+	}
+}
+
+func TestUpdateNvidiaCloudFunctionResourceModel_ArtifactDrift(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+	artifacts := func(artifactSchema schema.SetNestedAttribute, version string, uri string) types.Set {
+		return types.SetValueMust(artifactSchema.NestedObject.Type(), []attr.Value{
+			types.ObjectValueMust(artifactSchema.NestedObject.Type().(types.ObjectType).AttrTypes, map[string]attr.Value{
+				"name":    types.StringValue("mock-artifact"),
+				"version": types.StringValue(version),
+				"uri":     types.StringValue(uri),
+			}),
+		})
+	}
+	priorUri := "https://api.ngc.nvidia.com/v2/org/mock-org/models/mock-artifact/1.0/files"
+
+	data := NvidiaCloudFunctionResourceModel{
+		Models:    artifacts(modelsSchema(), "1.0", priorUri),
+		Resources: artifacts(resourcesSchema(), "1.0", priorUri),
+	}
+	// NVCF resolved the version and returns the URI without the registry host.
+	resolved := []utils.NvidiaCloudFunctionResource{{Name: "mock-artifact", Version: "1.0-sha256.mock-digest", URI: "/v2/org/mock-org/models/mock-artifact/1.0/files"}}
+	functionInfo := &utils.NvidiaCloudFunctionInfo{
+		Resources: resolved,
+		Models:    []utils.NvidiaCloudFunctionModel{{Name: resolved[0].Name, Version: resolved[0].Version, URI: resolved[0].URI}},
+	}
+
+	var diags diag.Diagnostics
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, functionInfo, nil, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	// The version drift is surfaced, while the normalized URI isn't reported as a change.
+	assert.True(t, artifacts(modelsSchema(), "1.0-sha256.mock-digest", priorUri).Equal(data.Models), data.Models)
+	assert.True(t, artifacts(resourcesSchema(), "1.0-sha256.mock-digest", priorUri).Equal(data.Resources), data.Resources)
+	assert.Equal(t, types.Int64Value(1), data.ModelsCount)
+	assert.Equal(t, types.Int64Value(1), data.ResourcesCount)
+
+	// A different artifact URI is drift as well.
+	functionInfo.Models[0].URI = "/v2/org/mock-org/models/mock-artifact/2.0/files"
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, functionInfo, nil, nil)
+
+	assert.True(t, artifacts(modelsSchema(), "1.0-sha256.mock-digest", "/v2/org/mock-org/models/mock-artifact/2.0/files").Equal(data.Models), data.Models)
+}
+
+func TestCreateOrUpdateRequest_HealthSupersedesHealthUri(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+	healthModel := &NvidiaCloudFunctionResourceHealthModel{}
+	health := types.ObjectValueMust(healthModel.attrTypes(), map[string]attr.Value{
+		"protocol":             types.StringValue("HTTP"),
+		"uri":                  types.StringValue("/v2/health/ready"),
+		"port":                 types.Int64Value(8000),
+		"timeout":              types.StringValue("PT10S"),
+		"expected_status_code": types.Int64Value(200),
+	})
+
+	var diags diag.Diagnostics
+	request := r.createOrUpdateRequest(ctx, NvidiaCloudFunctionResourceModel{HealthUri: types.StringValue("/health"), Health: health}, &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Empty(t, request.HealthUri)
+	assert.Equal(t, "/v2/health/ready", request.Health.URI)
+
+	request = r.createOrUpdateRequest(ctx, NvidiaCloudFunctionResourceModel{HealthUri: types.StringValue("/health"), Health: types.ObjectNull(healthModel.attrTypes())}, &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "/health", request.HealthUri)
+	assert.Nil(t, request.Health)
+}
+
+func TestVersionLabelRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+
+	var diags diag.Diagnostics
+	plan := NvidiaCloudFunctionResourceModel{
+		Tags:         types.SetValueMust(types.StringType, []attr.Value{types.StringValue("mock1")}),
+		VersionLabel: types.StringValue("canary"),
+	}
+	request := r.createOrUpdateRequest(ctx, plan, &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.ElementsMatch(t, []string{"mock1", "version-label:canary"}, request.Tags)
+
+	data := NvidiaCloudFunctionResourceModel{Tags: plan.Tags}
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{Tags: append(request.Tags, "nvcf-auto")}, nil, nil)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, types.StringValue("canary"), data.VersionLabel)
+	assert.True(t, plan.Tags.Equal(data.Tags))
+
+	// Imported resources don't have prior tags, the label tag still isn't tracked as a regular tag.
+	data = NvidiaCloudFunctionResourceModel{Tags: types.SetNull(types.StringType)}
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{Tags: request.Tags}, nil, nil)
+
+	assert.Equal(t, types.StringValue("canary"), data.VersionLabel)
+	assert.True(t, plan.Tags.Equal(data.Tags))
+
+	data = NvidiaCloudFunctionResourceModel{Tags: plan.Tags}
+	r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{Tags: []string{"mock1"}}, nil, nil)
+
+	assert.True(t, data.VersionLabel.IsNull())
+}
+
+// routingRoundTripper replies based on "METHOD path" and records the handled requests in order.
+// Like NVCF, it answers a GET of a path deleted successfully with a 404.
+type routingRoundTripper struct {
+	routes   map[string]*http.Response
+	requests []string
+	deleted  map[string]bool
+}
+
+func (rt *routingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := req.Method + " " + req.URL.Path
+	rt.requests = append(rt.requests, route)
+
+	// A route may also be specific to the query, e.g. for a page of a listing.
+	if resp, ok := rt.routes[route+"?"+req.URL.RawQuery]; ok && req.URL.RawQuery != "" {
+		return resp, nil
+	}
+
+	if resp, ok := rt.routes[route]; ok && !(req.Method == http.MethodGet && rt.deleted[req.URL.Path]) {
+		if req.Method == http.MethodDelete && resp.StatusCode < http.StatusMultipleChoices {
+			if rt.deleted == nil {
+				rt.deleted = make(map[string]bool)
+			}
+			rt.deleted[req.URL.Path] = true
+		}
+		return resp, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("{}")),
+	}, nil
+}
+
+func mockJsonResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestCancelPendingDeployment(t *testing.T) {
+	t.Parallel()
+
+	deploymentPath := "/v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-id"
+
+	tests := []struct {
+		name         string
+		status       string
+		wantRequests []string
+	}{
+		{
+			name:         "Deploying",
+			status:       "DEPLOYING",
+			wantRequests: []string{"GET " + deploymentPath, "DELETE " + deploymentPath, "GET " + deploymentPath},
+		},
+		{
+			name:         "Active",
+			status:       "ACTIVE",
+			wantRequests: []string{"GET " + deploymentPath},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &routingRoundTripper{routes: map[string]*http.Response{
+				"GET " + deploymentPath:    mockJsonResponse(http.StatusOK, `{"deployment": {"functionStatus": "`+tt.status+`"}}`),
+				"DELETE " + deploymentPath: mockJsonResponse(http.StatusOK, `{}`),
+			}}
+			r := &NvidiaCloudFunctionResource{
+				client: &utils.NVCFClient{
+					NgcEndpoint: "https://api.ngc.nvidia.com",
+					NgcApiKey:   "mock-api-key",
+					NgcOrg:      "mock-org",
+					HttpClient:  &http.Client{Transport: rt},
+				},
+			}
+
+			var diags diag.Diagnostics
+			r.cancelPendingDeployment(context.Background(), "mock-function-id", "mock-version-id", &diags)
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, tt.wantRequests, rt.requests)
+		})
+	}
+}
+
+func TestCancelPendingDeployment_NoDeployment(t *testing.T) {
+	t.Parallel()
+
+	rt := &routingRoundTripper{}
+	r := &NvidiaCloudFunctionResource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	var diags diag.Diagnostics
+	r.cancelPendingDeployment(context.Background(), "mock-function-id", "mock-version-id", &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Len(t, rt.requests, 1)
+}
+
+func TestDeleteOtherVersions(t *testing.T) {
+	t.Parallel()
+
+	versionsPath := "/v2/orgs/mock-org/nvcf/functions/mock-function-id/versions"
+	deploymentPath := "/v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions"
+
+	rt := &routingRoundTripper{routes: map[string]*http.Response{
+		"GET " + versionsPath: mockJsonResponse(http.StatusOK, `{"functions": [
+			{"id": "mock-function-id", "versionId": "mock-version-id", "status": "ACTIVE"},
+			{"id": "mock-function-id", "versionId": "mock-version-id-2", "status": "ACTIVE"},
+			{"id": "mock-function-id", "versionId": "mock-version-id-3", "status": "INACTIVE"}
+		]}`),
+		// A version serving requests can't be deleted before its deployment.
+		"GET " + deploymentPath + "/mock-version-id-2":    mockJsonResponse(http.StatusOK, `{"deployment": {"deploymentId": "mock-deployment-id", "functionStatus": "ACTIVE"}}`),
+		"DELETE " + deploymentPath + "/mock-version-id-2": mockJsonResponse(http.StatusOK, `{}`),
+		"DELETE " + versionsPath + "/mock-version-id-2":   mockJsonResponse(http.StatusNoContent, ``),
+		// The version was deleted concurrently, it's gone either way.
+		"DELETE " + versionsPath + "/mock-version-id-3": mockJsonResponse(http.StatusNotFound, `{"detail": "Not found"}`),
+	}}
+	r := &NvidiaCloudFunctionResource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	var diags diag.Diagnostics
+	r.deleteOtherVersions(context.Background(), "mock-function-id", "mock-version-id", &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{
+		"GET " + versionsPath,
+		"GET " + deploymentPath + "/mock-version-id-2",
+		"DELETE " + deploymentPath + "/mock-version-id-2",
+		"GET " + deploymentPath + "/mock-version-id-2",
+		"DELETE " + versionsPath + "/mock-version-id-2",
+		"GET " + deploymentPath + "/mock-version-id-3",
+		"DELETE " + versionsPath + "/mock-version-id-3",
+	}, rt.requests)
+}
+
+func TestProviderVersionTag(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	plan := NvidiaCloudFunctionResourceModel{
+		Tags: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("mock1")}),
+	}
+
+	var diags diag.Diagnostics
+	disabled := &NvidiaCloudFunctionResource{}
+	assert.ElementsMatch(t, []string{"mock1"}, disabled.createOrUpdateRequest(ctx, plan, &diags).Tags)
+
+	enabled := &NvidiaCloudFunctionResource{providerVersion: "1.2.0"}
+	request := enabled.createOrUpdateRequest(ctx, plan, &diags)
+	assert.ElementsMatch(t, []string{"mock1", "managed_by_provider_version:1.2.0"}, request.Tags)
+
+	// The tag isn't tracked in tags, so it doesn't cause drift.
+	data := NvidiaCloudFunctionResourceModel{Tags: plan.Tags}
+	enabled.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{Tags: request.Tags}, nil, nil)
+	assert.True(t, plan.Tags.Equal(data.Tags))
+
+	data = NvidiaCloudFunctionResourceModel{Tags: types.SetNull(types.StringType)}
+	enabled.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{Tags: request.Tags}, nil, nil)
+	assert.True(t, plan.Tags.Equal(data.Tags))
+
+	assert.False(t, diags.HasError(), diags)
+}
+
+func TestValidateDeploymentTargets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	spec := func(backend types.String, clusters types.Set) NvidiaCloudFunctionResourceDeploymentSpecificationModel {
+		return NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+			GpuSpecificationID:    types.StringUnknown(),
+			GpuType:               types.StringValue("L40"),
+			Backend:               backend,
+			MaxInstances:          types.Int64Value(1),
+			MinInstances:          types.Int64Value(1),
+			MaxRequestConcurrency: types.Int64Value(1),
+			Configuration:         types.StringNull(),
+			InstanceType:          types.StringValue("DGX-CLOUD.GPU.L40_1x"),
+			Clusters:              clusters,
+			Regions:               types.SetNull(types.StringType),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		spec    NvidiaCloudFunctionResourceDeploymentSpecificationModel
+		wantErr bool
+	}{
+		{
+			name: "ClustersSet",
+			spec: spec(types.StringNull(), types.SetValueMust(types.StringType, []attr.Value{types.StringValue("mock-cluster")})),
+		},
+		{
+			name: "BackendSet",
+			spec: spec(types.StringValue("GFN"), types.SetNull(types.StringType)),
+		},
+		{
+			name: "ClustersUnknown",
+			spec: spec(types.StringNull(), types.SetUnknown(types.StringType)),
+		},
+		{
+			name:    "MissingBackendAndClusters",
+			spec:    spec(types.StringNull(), types.SetNull(types.StringType)),
+			wantErr: true,
+		},
+		{
+			name:    "EmptyClusters",
+			spec:    spec(types.StringNull(), types.SetValueMust(types.StringType, nil)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			specs, d := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceDeploymentSpecificationModel{tt.spec})
+			assert.False(t, d.HasError(), d)
+
+			validateDeploymentTargets(ctx, NvidiaCloudFunctionResourceModel{DeploymentSpecifications: specs}, &diags)
+
+			assert.Equal(t, tt.wantErr, diags.HasError(), diags)
+		})
+	}
+}
+
+func TestValidateConfigurationSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	configurationSchema := `{"type": "object", "additionalProperties": false, "properties": {"replicaCount": {"type": "integer"}}}`
+	spec := func(configuration types.String) NvidiaCloudFunctionResourceDeploymentSpecificationModel {
+		return NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+			GpuSpecificationID:    types.StringUnknown(),
+			GpuType:               types.StringValue("L40"),
+			Backend:               types.StringValue("GFN"),
+			MaxInstances:          types.Int64Value(1),
+			MinInstances:          types.Int64Value(1),
+			MaxRequestConcurrency: types.Int64Value(1),
+			Configuration:         configuration,
+			InstanceType:          types.StringValue("gl40_1.br20_2xlarge"),
+			Clusters:              types.SetNull(types.StringType),
+			Regions:               types.SetNull(types.StringType),
+		}
+	}
+
+	tests := []struct {
+		name                string
+		configurationSchema types.String
+		spec                NvidiaCloudFunctionResourceDeploymentSpecificationModel
+		wantErr             string
+	}{
+		{
+			name:                "Matching",
+			configurationSchema: types.StringValue(configurationSchema),
+			spec:                spec(types.StringValue(`{"replicaCount": 2}`)),
+		},
+		{
+			name:                "UnknownKey",
+			configurationSchema: types.StringValue(configurationSchema),
+			spec:                spec(types.StringValue(`{"replicas": 2}`)),
+			wantErr:             "$.replicas: unknown property",
+		},
+		{
+			name:                "NoConfiguration",
+			configurationSchema: types.StringValue(configurationSchema),
+			spec:                spec(types.StringNull()),
+		},
+		{
+			name:                "NoSchema",
+			configurationSchema: types.StringNull(),
+			spec:                spec(types.StringValue(`{"replicas": 2}`)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			specs, d := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceDeploymentSpecificationModel{tt.spec})
+			assert.False(t, d.HasError(), d)
+
+			validateConfigurationSchema(ctx, NvidiaCloudFunctionResourceModel{DeploymentSpecifications: specs, ConfigurationSchema: tt.configurationSchema}, &diags)
+
+			if tt.wantErr == "" {
+				assert.False(t, diags.HasError(), diags)
+				return
+			}
+			assert.Len(t, diags.Errors(), 1)
+			assert.Contains(t, diags.Errors()[0].Detail(), tt.wantErr)
+		})
+	}
+}
+
+func TestRecreateReason(t *testing.T) {
+	t.Parallel()
+
+	state := NvidiaCloudFunctionResourceModel{
+		FunctionName:         types.StringValue("mock-function"),
+		ContainerImage:       types.StringValue("nvcr.io/mock-org/mock-image:1.0.0"),
+		ContainerEnvironment: types.SetNull(containerEnvironmentsSchema().NestedObject.Type()),
+		Resources:            types.SetNull(resourcesSchema().NestedObject.Type()),
+		Models:               types.SetNull(modelsSchema().NestedObject.Type()),
+		Tags:                 types.SetValueMust(types.StringType, []attr.Value{types.StringValue("mock1")}),
+	}
+
+	plan := state
+	plan.ContainerImage = types.StringValue("nvcr.io/mock-org/mock-image:1.1.0")
+	assert.Equal(t, []string{"container_image"}, recreateReason(state, plan))
+
+	// Tags are updated in place, they never recreate the version.
+	plan = state
+	plan.Tags = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("mock2")})
+	assert.Empty(t, recreateReason(state, plan))
+}
+
+func TestCreateDeployment_WithoutWaitForActive(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	deploymentPath := "/v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-id"
+
+	rt := &routingRoundTripper{routes: map[string]*http.Response{
+		"POST " + deploymentPath: mockJsonResponse(http.StatusOK, `{"deployment": {"deploymentId": "mock-deployment-id", "functionStatus": "DEPLOYING"}}`),
+	}}
+	r := &NvidiaCloudFunctionResource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	specs, d := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+		{
+			GpuSpecificationID:    types.StringUnknown(),
+			GpuType:               types.StringValue("L40"),
+			Backend:               types.StringValue("GFN"),
+			MaxInstances:          types.Int64Value(1),
+			MinInstances:          types.Int64Value(1),
+			MaxRequestConcurrency: types.Int64Value(1),
+			Configuration:         types.StringNull(),
+			InstanceType:          types.StringValue("gl40_1.br20_2xlarge"),
+			Clusters:              types.SetNull(types.StringType),
+			Regions:               types.SetNull(types.StringType),
+		},
+	})
+	assert.False(t, d.HasError(), d)
+
+	data := NvidiaCloudFunctionResourceModel{
+		DeploymentSpecifications: specs,
+		WaitForActive:            types.BoolValue(false),
+	}
+
+	var diags diag.Diagnostics
+	deployment := r.createDeployment(ctx, data, &diags, utils.NvidiaCloudFunctionInfo{ID: "mock-function-id", VersionID: "mock-version-id"})
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "DEPLOYING", deployment.FunctionStatus)
+	// Only the deployment creation is sent, the status is never polled.
+	assert.Equal(t, []string{"POST " + deploymentPath}, rt.requests)
+}
+
+// sequenceRoundTripper replies with the given responses in order, repeating the last one.
+type sequenceRoundTripper struct {
+	responses []func() *http.Response
+	calls     int
+}
+
+func (rt *sequenceRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	i := min(rt.calls, len(rt.responses)-1)
+	rt.calls++
+	return rt.responses[i](), nil
+}
+
+// recordingClock records the waits instead of sleeping, or blocks them when block is set.
+type recordingClock struct {
+	waits []time.Duration
+	block bool
+}
+
+func (c *recordingClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+
+	ch := make(chan time.Time, 1)
+	if !c.block {
+		ch <- time.Time{}
+	}
+	return ch
+}
+
+func TestCreateDeployment_CreateRetry(t *testing.T) {
+	t.Parallel()
+
+	quotaExceeded := func() *http.Response {
+		return mockJsonResponse(http.StatusBadRequest, `{"requestStatus": {"statusCode": "INVALID_REQUEST", "statusDescription": "Validation failed - [All allocated GPU instances in use - contact your support team]"}}`)
+	}
+	created := func() *http.Response {
+		return mockJsonResponse(http.StatusOK, `{"deployment": {"deploymentId": "mock-deployment-id", "functionStatus": "DEPLOYING"}}`)
+	}
+
+	tests := []struct {
+		name        string
+		createRetry bool
+		wantErr     bool
+		wantCalls   int
+		wantWaits   []time.Duration
+	}{
+		{
+			name:        "RetriesTransientFailure",
+			createRetry: true,
+			wantCalls:   3,
+			wantWaits:   []time.Duration{15 * time.Second, 30 * time.Second},
+		},
+		{
+			name:        "FailsWithoutCreateRetry",
+			createRetry: false,
+			wantErr:     true,
+			wantCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			rt := &sequenceRoundTripper{responses: []func() *http.Response{quotaExceeded, quotaExceeded, created}}
+			clock := &recordingClock{}
+			r := &NvidiaCloudFunctionResource{
+				client: &utils.NVCFClient{
+					NgcEndpoint: "https://api.ngc.nvidia.com",
+					NgcApiKey:   "mock-api-key",
+					NgcOrg:      "mock-org",
+					HttpClient:  &http.Client{Transport: rt},
+					Clock:       clock,
+				},
+			}
+
+			specs, d := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+				{
+					GpuSpecificationID:    types.StringUnknown(),
+					GpuType:               types.StringValue("L40"),
+					Backend:               types.StringValue("GFN"),
+					MaxInstances:          types.Int64Value(1),
+					MinInstances:          types.Int64Value(1),
+					MaxRequestConcurrency: types.Int64Value(1),
+					Configuration:         types.StringNull(),
+					InstanceType:          types.StringValue("gl40_1.br20_2xlarge"),
+					Clusters:              types.SetNull(types.StringType),
+					Regions:               types.SetNull(types.StringType),
+				},
+			})
+			assert.False(t, d.HasError(), d)
+
+			data := NvidiaCloudFunctionResourceModel{
+				DeploymentSpecifications: specs,
+				WaitForActive:            types.BoolValue(false),
+				CreateRetry:              types.BoolValue(tt.createRetry),
+			}
+
+			var diags diag.Diagnostics
+			deployment := r.createDeployment(ctx, data, &diags, utils.NvidiaCloudFunctionInfo{ID: "mock-function-id", VersionID: "mock-version-id"})
+
+			assert.Equal(t, tt.wantErr, diags.HasError(), diags)
+			assert.Equal(t, tt.wantCalls, rt.calls)
+			// The backoff doubles on the client clock.
+			assert.Equal(t, tt.wantWaits, clock.waits)
+			if !tt.wantErr {
+				assert.Equal(t, "mock-deployment-id", deployment.DeploymentID)
+			}
+		})
+	}
+}
+
+func TestCreateDeployment_ScaledToZero(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	spec := `"deploymentSpecifications": [{"gpu": "L40", "instanceType": "gl40_1.br20_2xlarge", "minInstances": 0, "maxInstances": 1}]`
+	rt := &sequenceRoundTripper{responses: []func() *http.Response{
+		func() *http.Response {
+			return mockJsonResponse(http.StatusOK, `{"deployment": {"deploymentId": "mock-deployment-id", "functionStatus": "DEPLOYING", `+spec+`}}`)
+		},
+		func() *http.Response {
+			return mockJsonResponse(http.StatusOK, `{"deployment": {"deploymentId": "mock-deployment-id", "functionStatus": "INACTIVE", `+spec+`}}`)
+		},
+	}}
+	r := &NvidiaCloudFunctionResource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	specs, d := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+		{
+			GpuSpecificationID:    types.StringUnknown(),
+			GpuType:               types.StringValue("L40"),
+			Backend:               types.StringValue("GFN"),
+			MaxInstances:          types.Int64Value(1),
+			MinInstances:          types.Int64Value(0),
+			MaxRequestConcurrency: types.Int64Value(1),
+			Configuration:         types.StringNull(),
+			InstanceType:          types.StringValue("gl40_1.br20_2xlarge"),
+			Clusters:              types.SetNull(types.StringType),
+			Regions:               types.SetNull(types.StringType),
+		},
+	})
+	assert.False(t, d.HasError(), d)
+
+	data := NvidiaCloudFunctionResourceModel{
+		DeploymentSpecifications: specs,
+		WaitForActive:            types.BoolValue(true),
+	}
+
+	var diags diag.Diagnostics
+	deployment := r.createDeployment(ctx, data, &diags, utils.NvidiaCloudFunctionInfo{ID: "mock-function-id", VersionID: "mock-version-id"})
+
+	// A deployment without any instance settles INACTIVE, which completes the wait.
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "INACTIVE", deployment.FunctionStatus)
+	// The creation, the wait, then the read of the settled status.
+	assert.Equal(t, 3, rt.calls)
+}
+
+func TestRetryTransientCreateError_StopsWhenContextDone(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	err := retryTransientCreateError(ctx, &recordingClock{block: true}, true, func() error {
+		calls++
+		return fmt.Errorf("wrapped: %w", utils.ErrQuotaExceeded)
+	})
+
+	assert.ErrorIs(t, err, utils.ErrQuotaExceeded)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWaitWithinMaxDeploymentWait(t *testing.T) {
+	t.Parallel()
+
+	waitUntilDone := func(ctx context.Context) error {
+		<-ctx.Done()
+		return errors.New("timeout occurred")
+	}
+
+	t.Run("MaxDeploymentWaitShorter", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+
+		err := waitWithinMaxDeploymentWait(ctx, types.StringValue("10ms"), waitUntilDone)
+
+		assert.EqualError(t, err, "deployment not completed within max_deployment_wait 10ms: timeout occurred")
+		assert.NoError(t, ctx.Err())
+	})
+
+	t.Run("TimeoutShorter", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := waitWithinMaxDeploymentWait(ctx, types.StringValue("1h"), waitUntilDone)
+
+		assert.EqualError(t, err, "timeout occurred")
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		t.Parallel()
+
+		err := waitWithinMaxDeploymentWait(context.Background(), types.StringNull(), func(ctx context.Context) error {
+			_, ok := ctx.Deadline()
+			assert.False(t, ok)
+			return nil
+		})
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestParseFunctionImportID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		id            string
+		wantFunction  string
+		wantVersion   string
+		wantErrSubstr string
+	}{
+		{
+			name:         "Valid",
+			id:           "a,b",
+			wantFunction: "a",
+			wantVersion:  "b",
+		},
+		{
+			name:         "Trimmed",
+			id:           "a, b",
+			wantFunction: "a",
+			wantVersion:  "b",
+		},
+		{
+			name:          "MissingVersion",
+			id:            "a",
+			wantErrSubstr: `Got 1 part(s) in: "a"`,
+		},
+		{
+			name:          "EmptyVersion",
+			id:            "a, ",
+			wantErrSubstr: `Got 2 part(s) in: "a, "`,
+		},
+		{
+			name:          "TooManyParts",
+			id:            "a,b,c",
+			wantErrSubstr: `Got 3 part(s) in: "a,b,c"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			functionID, versionID, err := parseFunctionImportID(tt.id)
+
+			if tt.wantErrSubstr != "" {
+				assert.ErrorContains(t, err, "function_id,version_id")
+				assert.ErrorContains(t, err, tt.wantErrSubstr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFunction, functionID)
+			assert.Equal(t, tt.wantVersion, versionID)
+		})
+	}
+}
+
+func TestCheckFunctionManageable(t *testing.T) {
+	t.Parallel()
+
+	versionsPath := "/v2/orgs/mock-org/nvcf/functions/mock-function-id/versions"
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{
+			name:    "SharedFunction",
+			body:    `{"functions": [{"id": "mock-function-id", "versionId": "mock-version-id", "ncaId": "mock-other-nca-id", "ownedByDifferentAccount": true}]}`,
+			wantErr: true,
+		},
+		{
+			name: "OwnedFunction",
+			body: `{"functions": [{"id": "mock-function-id", "versionId": "mock-version-id", "ncaId": "mock-nca-id", "ownedByDifferentAccount": false}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &routingRoundTripper{routes: map[string]*http.Response{
+				"GET " + versionsPath: mockJsonResponse(http.StatusOK, tt.body),
+			}}
+			r := &NvidiaCloudFunctionResource{
+				client: &utils.NVCFClient{
+					NgcEndpoint: "https://api.ngc.nvidia.com",
+					NgcApiKey:   "mock-api-key",
+					NgcOrg:      "mock-org",
+					HttpClient:  &http.Client{Transport: rt},
+				},
+			}
+
+			var diags diag.Diagnostics
+			r.checkFunctionManageable(context.Background(), "mock-function-id", &diags)
+
+			assert.Equal(t, tt.wantErr, diags.HasError(), diags)
+			if tt.wantErr {
+				assert.Equal(t, "Cloud Function Not Manageable", diags.Errors()[0].Summary())
+				assert.Contains(t, diags.Errors()[0].Detail(), "mock-other-nca-id")
+			}
+		})
+	}
+}
+
+func TestValidateDeploymentSpecificationsPresent(t *testing.T) {
+	t.Parallel()
+
+	specType := deploymentSpecificationsSchema().NestedObject.Type()
+
+	tests := []struct {
+		name        string
+		specs       types.Set
+		wantWarning bool
+	}{
+		{
+			name:        "NotSet",
+			specs:       types.SetNull(specType),
+			wantWarning: true,
+		},
+		{
+			name:        "Empty",
+			specs:       types.SetValueMust(specType, nil),
+			wantWarning: true,
+		},
+		{
+			name:  "Unknown",
+			specs: types.SetUnknown(specType),
+		},
+		{
+			name:  "Set",
+			specs: types.SetValueMust(specType, []attr.Value{types.ObjectUnknown(specType.(types.ObjectType).AttrTypes)}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			validateDeploymentSpecificationsPresent(NvidiaCloudFunctionResourceModel{DeploymentSpecifications: tt.specs}, &diags)
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, tt.wantWarning, diags.WarningsCount() == 1, diags)
+		})
+	}
+}
+
+func TestResolveInstanceTypes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rt := &routingRoundTripper{routes: map[string]*http.Response{
+		"GET /v2/orgs/mock-org/nvcf/clusterGroups": mockJsonResponse(http.StatusOK, `{"clusterGroups": [{"name": "GFN", "gpus": [
+			{"name": "L40", "instanceTypes": [{"name": "gl40_2.br20_4xlarge", "gpuCount": 2}, {"name": "gl40_1.br20_2xlarge", "gpuCount": 1}]},
+			{"name": "T10", "instanceTypes": [{"name": "g6.full", "gpuCount": 1}]}
+		]}]}`),
+	}}
+	client := &utils.NVCFClient{
+		NgcEndpoint: "https://api.ngc.nvidia.com",
+		NgcApiKey:   "mock-api-key",
+		NgcOrg:      "mock-org",
+		HttpClient:  &http.Client{Transport: rt},
+	}
+
+	deployed, diags := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+		{
+			GpuSpecificationID:    types.StringValue("mock-gpu-specification-id"),
+			GpuType:               types.StringValue("T10"),
+			InstanceType:          types.StringValue("g6.2x"),
+			Backend:               types.StringValue("GFN"),
+			MaxInstances:          types.Int64Value(1),
+			MinInstances:          types.Int64Value(1),
+			MaxRequestConcurrency: types.Int64Value(1),
+			Configuration:         types.StringNull(),
+			Clusters:              types.SetNull(types.StringType),
+			Regions:               types.SetNull(types.StringType),
+		},
+	})
+	assert.False(t, diags.HasError(), diags)
+
+	specs := []utils.NvidiaCloudFunctionDeploymentSpecification{
+		{Gpu: "L40", Backend: "GFN"},
+		{Gpu: "T10", Backend: "GFN"},
+		{Gpu: "L40", Backend: "GFN", InstanceType: "gl40_2.br20_4xlarge"},
+	}
+	resolveInstanceTypes(ctx, client, specs, deployed, &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "gl40_1.br20_2xlarge", specs[0].InstanceType)
+	// The deployed instance type is kept over the cheapest one.
+	assert.Equal(t, "g6.2x", specs[1].InstanceType)
+	assert.Equal(t, "gl40_2.br20_4xlarge", specs[2].InstanceType)
+	assert.Equal(t, []string{"GET /v2/orgs/mock-org/nvcf/clusterGroups"}, rt.requests)
+
+	specs = []utils.NvidiaCloudFunctionDeploymentSpecification{{Gpu: "H100", Backend: "GFN"}}
+	resolveInstanceTypes(ctx, client, specs, types.SetNull(deploymentSpecificationsSchema().NestedObject.Type()), &diags)
+
+	assert.True(t, diags.HasError())
+}
+
+func TestValidateInstanceTypes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	spec := func(instanceType types.String) NvidiaCloudFunctionResourceDeploymentSpecificationModel {
+		return NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+			GpuSpecificationID:    types.StringUnknown(),
+			GpuType:               types.StringValue("L40"),
+			Backend:               types.StringValue("GFN"),
+			MaxInstances:          types.Int64Value(1),
+			MinInstances:          types.Int64Value(1),
+			MaxRequestConcurrency: types.Int64Value(1),
+			Configuration:         types.StringNull(),
+			InstanceType:          instanceType,
+			Clusters:              types.SetNull(types.StringType),
+			Regions:               types.SetNull(types.StringType),
+		}
+	}
+
+	tests := []struct {
+		name             string
+		instanceType     types.String
+		autoInstanceType types.Bool
+		wantErr          bool
+	}{
+		{name: "InstanceTypeSet", instanceType: types.StringValue("gl40_1.br20_2xlarge"), autoInstanceType: types.BoolNull()},
+		{name: "AutoInstanceType", instanceType: types.StringNull(), autoInstanceType: types.BoolValue(true)},
+		{name: "MissingInstanceType", instanceType: types.StringNull(), autoInstanceType: types.BoolNull(), wantErr: true},
+		{name: "MissingInstanceTypeAutoDisabled", instanceType: types.StringNull(), autoInstanceType: types.BoolValue(false), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			specs, d := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), []NvidiaCloudFunctionResourceDeploymentSpecificationModel{spec(tt.instanceType)})
+			assert.False(t, d.HasError(), d)
+
+			validateInstanceTypes(ctx, NvidiaCloudFunctionResourceModel{DeploymentSpecifications: specs, AutoInstanceType: tt.autoInstanceType}, &diags)
+
+			assert.Equal(t, tt.wantErr, diags.HasError(), diags)
+		})
+	}
+}
+
+func TestModifyPlan_AutoInstanceTypeAfterApply(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	spec := func(gpuSpecificationID types.String, instanceType types.String) types.Set {
+		return types.SetValueMust(deploymentSpecificationsSchema().NestedObject.Type(), []attr.Value{
+			types.ObjectValueMust(deploymentSpecificationsSchema().NestedObject.Type().(types.ObjectType).AttrTypes, map[string]attr.Value{
+				"gpu_specification_id":    gpuSpecificationID,
+				"gpu_type":                types.StringValue("L40"),
+				"backend":                 types.StringValue("GFN"),
+				"max_instances":           types.Int64Value(1),
+				"min_instances":           types.Int64Value(1),
+				"max_request_concurrency": types.Int64Value(1),
+				"configuration":           types.StringNull(),
+				"instance_type":           instanceType,
+				"clusters":                types.SetNull(types.StringType),
+				"regions":                 types.SetNull(types.StringType),
+			}),
+		})
+	}
+	// rawValue builds the resource value holding only the given deployment specifications.
+	rawValue := func(specs types.Set) tftypes.Value {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		diags := state.SetAttribute(ctx, path.Root("auto_instance_type"), types.BoolValue(true))
+		diags.Append(state.SetAttribute(ctx, path.Root("deployment_specifications"), specs)...)
+		assert.False(t, diags.HasError(), diags)
+		return state.Raw
+	}
+
+	// The instance type resolved on apply is in state, the configuration leaves it unset.
+	applied := spec(types.StringValue("mock-gpu-specification-id"), types.StringValue("gl40_1.br20_2xlarge"))
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: rawValue(spec(types.StringNull(), types.StringNull()))},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: rawValue(spec(types.StringValue("mock-gpu-specification-id"), types.StringUnknown()))},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: rawValue(applied)},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	r.ModifyPlan(ctx, req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var planned types.Set
+	assert.False(t, resp.Plan.GetAttribute(ctx, path.Root("deployment_specifications"), &planned).HasError())
+	assert.True(t, planned.Equal(applied), "no diff expected, planned %s", planned)
+}
+
+func TestModifyPlan_ReplaceAdoptedVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	// rawValue builds the resource value holding only the given version and container image.
+	rawValue := func(versionID types.String, containerImage string) tftypes.Value {
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		diags := state.SetAttribute(ctx, path.Root("id"), types.StringValue("mock-function-id"))
+		diags.Append(state.SetAttribute(ctx, path.Root("version_id"), versionID)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("container_image"), types.StringValue(containerImage))...)
+		assert.False(t, diags.HasError(), diags)
+		return state.Raw
+	}
+
+	tests := []struct {
+		name            string
+		configVersionID types.String
+		containerImage  string
+		wantErr         bool
+	}{
+		{
+			// The replacement would delete the pinned version before adopting it.
+			name:            "ReplacePinnedVersion",
+			configVersionID: types.StringValue("mock-version-id"),
+			containerImage:  "nvcr.io/mock-org/mock-image:2.0.0",
+			wantErr:         true,
+		},
+		{
+			name:            "ReplaceUnpinnedVersion",
+			configVersionID: types.StringNull(),
+			containerImage:  "nvcr.io/mock-org/mock-image:2.0.0",
+		},
+		{
+			name:            "PinAnotherVersion",
+			configVersionID: types.StringValue("mock-version-id-2"),
+			containerImage:  "nvcr.io/mock-org/mock-image:2.0.0",
+		},
+		{
+			name:            "NoReplace",
+			configVersionID: types.StringValue("mock-version-id"),
+			containerImage:  "nvcr.io/mock-org/mock-image:1.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plannedVersionID := tt.configVersionID
+			if plannedVersionID.IsNull() {
+				plannedVersionID = types.StringUnknown()
+			}
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: rawValue(tt.configVersionID, tt.containerImage)},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: rawValue(plannedVersionID, tt.containerImage)},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: rawValue(types.StringValue("mock-version-id"), "nvcr.io/mock-org/mock-image:1.0.0")},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, resp)
+
+			// The private state isn't available outside of the framework, only the replacement error is checked.
+			var summaries []string
+			for _, d := range resp.Diagnostics.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			assert.Equal(t, tt.wantErr, slices.Contains(summaries, "Cloud Function Version Replacement"), resp.Diagnostics)
+		})
+	}
+}
+
+func TestPlanInstanceTypes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	spec := func(gpuType string, instanceType types.String) NvidiaCloudFunctionResourceDeploymentSpecificationModel {
+		return NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+			GpuSpecificationID:    types.StringNull(),
+			GpuType:               types.StringValue(gpuType),
+			Backend:               types.StringValue("GFN"),
+			MaxInstances:          types.Int64Value(1),
+			MinInstances:          types.Int64Value(1),
+			MaxRequestConcurrency: types.Int64Value(1),
+			Configuration:         types.StringNull(),
+			InstanceType:          instanceType,
+			Clusters:              types.SetNull(types.StringType),
+			Regions:               types.SetNull(types.StringType),
+		}
+	}
+	set := func(specs ...NvidiaCloudFunctionResourceDeploymentSpecificationModel) types.Set {
+		value, diags := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), specs)
+		assert.False(t, diags.HasError(), diags)
+		return value
+	}
+
+	// A GPU added next to a deployed one is only resolved on apply.
+	config := set(spec("L40", types.StringNull()), spec("A100", types.StringNull()))
+	plan := set(spec("L40", types.StringUnknown()), spec("A100", types.StringUnknown()))
+	state := set(spec("L40", types.StringValue("gl40_1.br20_2xlarge")))
+
+	var diags diag.Diagnostics
+	planned := planInstanceTypes(ctx, config, plan, state, &diags)
+
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, planned.Equal(set(spec("L40", types.StringValue("gl40_1.br20_2xlarge")), spec("A100", types.StringUnknown()))), "planned %s", planned)
+}
+
+func TestValidateHelmContainerSettings(t *testing.T) {
+	t.Parallel()
+
+	containerEnvironment := types.SetValueMust(containerEnvironmentsSchema().NestedObject.Type(), []attr.Value{
+		types.ObjectValueMust(map[string]attr.Type{"key": types.StringType, "value": types.StringType}, map[string]attr.Value{
+			"key":   types.StringValue("mock_key"),
+			"value": types.StringValue("mock_value"),
+		}),
+	})
+
+	tests := []struct {
+		name       string
+		data       NvidiaCloudFunctionResourceModel
+		wantErrors int
+	}{
+		{
+			name: "ContainerFunction",
+			data: NvidiaCloudFunctionResourceModel{
+				HelmChart:            types.StringNull(),
+				ContainerEnvironment: containerEnvironment,
+				ContainerArgs:        types.StringValue("--mock-arg"),
+			},
+		},
+		{
+			name: "HelmFunction",
+			data: NvidiaCloudFunctionResourceModel{
+				HelmChart:            types.StringValue("https://helm.ngc.nvidia.com/mock-org/charts/mock-chart-1.0.0.tgz"),
+				ContainerEnvironment: types.SetNull(containerEnvironmentsSchema().NestedObject.Type()),
+				ContainerArgs:        types.StringNull(),
+			},
+		},
+		{
+			name: "HelmFunctionWithContainerSettings",
+			data: NvidiaCloudFunctionResourceModel{
+				HelmChart:            types.StringValue("https://helm.ngc.nvidia.com/mock-org/charts/mock-chart-1.0.0.tgz"),
+				ContainerEnvironment: containerEnvironment,
+				ContainerArgs:        types.StringValue("--mock-arg"),
+			},
+			wantErrors: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateHelmContainerSettings(tt.data, &diags)
+
+			assert.Equal(t, tt.wantErrors, diags.ErrorsCount(), diags)
+		})
+	}
+}
+
+func TestPreserveDeploymentSpecExtras(t *testing.T) {
+	t.Parallel()
+
+	deployedSpecs := []utils.NvidiaCloudFunctionDeploymentSpecification{
+		{Gpu: "L40", InstanceType: "gl40_1.br20_2xlarge", Extras: map[string]json.RawMessage{"preferredOrder": json.RawMessage(`3`)}},
+		{Gpu: "H100", InstanceType: "DGX-CLOUD.GPU.H100_1x"},
+	}
+	planSpecs := []utils.NvidiaCloudFunctionDeploymentSpecification{
+		{Gpu: "L40", InstanceType: "gl40_1.br20_2xlarge", MaxInstances: 2},
+		{Gpu: "H100", InstanceType: "DGX-CLOUD.GPU.H100_1x", MaxInstances: 2},
+		{Gpu: "A100", InstanceType: "DGX-CLOUD.GPU.A100_1x", MaxInstances: 1},
+	}
+
+	preserveDeploymentSpecExtras(planSpecs, deployedSpecs)
+
+	assert.Equal(t, map[string]json.RawMessage{"preferredOrder": json.RawMessage(`3`)}, planSpecs[0].Extras)
+	assert.Nil(t, planSpecs[1].Extras)
+	assert.Nil(t, planSpecs[2].Extras)
+
+	data, err := json.Marshal(planSpecs[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"preferredOrder":3`)
+}
+
+func TestAdoptVersion(t *testing.T) {
+	t.Parallel()
+
+	versionPath := "/v2/orgs/mock-org/nvcf/functions/mock-function-id/versions/mock-version-id"
+	deploymentPath := "/v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-id"
+	authorizationPath := "/v2/orgs/mock-org/nvcf/authorizations/functions/mock-function-id/versions/mock-version-id"
+	version := `{"function": {"id": "mock-function-id", "versionId": "mock-version-id", "name": "mock-function", "status": "ACTIVE",
+		"containerImage": "nvcr.io/mock-org/mock-image:1.0.0", "inferenceUrl": "/predict", "inferencePort": 8000, "tags": ["mock2"]}}`
+
+	specType := deploymentSpecificationsSchema().NestedObject.Type()
+	// The computed gpu_specification_id is only known once adopted.
+	specs := func(maxInstances int64) types.Set {
+		return types.SetValueMust(specType, []attr.Value{
+			types.ObjectValueMust(specType.(types.ObjectType).AttrTypes, map[string]attr.Value{
+				"gpu_specification_id":    types.StringUnknown(),
+				"gpu_type":                types.StringValue("L40"),
+				"backend":                 types.StringValue("GFN"),
+				"max_instances":           types.Int64Value(maxInstances),
+				"min_instances":           types.Int64Value(1),
+				"max_request_concurrency": types.Int64Value(1),
+				"configuration":           types.StringNull(),
+				"instance_type":           types.StringValue("gl40_1.br20_2xlarge"),
+				"clusters":                types.SetNull(types.StringType),
+				"regions":                 types.SetNull(types.StringType),
+			}),
+		})
+	}
+
+	tests := []struct {
+		name                     string
+		versionStatus            int
+		containerImage           string
+		tags                     []attr.Value
+		deploymentSpecifications types.Set
+		wantErr                  string
+	}{
+		{
+			name:                     "Adopted",
+			versionStatus:            http.StatusOK,
+			containerImage:           "nvcr.io/mock-org/mock-image:1.0.0",
+			deploymentSpecifications: specs(1),
+		},
+		{
+			name:                     "TagsMismatch",
+			versionStatus:            http.StatusOK,
+			containerImage:           "nvcr.io/mock-org/mock-image:1.0.0",
+			tags:                     []attr.Value{types.StringValue("mock1")},
+			deploymentSpecifications: specs(1),
+			wantErr:                  "Cloud Function Version Mismatch",
+		},
+		{
+			name:                     "DeploymentMismatch",
+			versionStatus:            http.StatusOK,
+			containerImage:           "nvcr.io/mock-org/mock-image:1.0.0",
+			deploymentSpecifications: specs(2),
+			wantErr:                  "Cloud Function Version Mismatch",
+		},
+		{
+			name:           "NotFound",
+			versionStatus:  http.StatusNotFound,
+			containerImage: "nvcr.io/mock-org/mock-image:1.0.0",
+			wantErr:        "Cloud Function Version Not Found",
+		},
+		{
+			name:           "Mismatch",
+			versionStatus:  http.StatusOK,
+			containerImage: "nvcr.io/mock-org/mock-image:2.0.0",
+			wantErr:        "Cloud Function Version Mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body := version
+			if tt.versionStatus == http.StatusNotFound {
+				body = `{"detail": "Not found"}`
+			}
+			rt := &routingRoundTripper{routes: map[string]*http.Response{
+				"GET " + versionPath: mockJsonResponse(tt.versionStatus, body),
+				"GET " + deploymentPath: mockJsonResponse(http.StatusOK, `{"deployment": {"deploymentId": "mock-deployment-id", "functionStatus": "ACTIVE",
+					"deploymentSpecifications": [{"gpu": "L40", "backend": "GFN", "instanceType": "gl40_1.br20_2xlarge", "minInstances": 1, "maxInstances": 1, "maxRequestConcurrency": 1}]}}`),
+				"GET " + authorizationPath: mockJsonResponse(http.StatusOK, `{"function": {"id": "mock-function-id", "versionId": "mock-version-id"}}`),
+			}}
+			r := &NvidiaCloudFunctionResource{
+				client: &utils.NVCFClient{
+					NgcEndpoint: "https://api.ngc.nvidia.com",
+					NgcApiKey:   "mock-api-key",
+					NgcOrg:      "mock-org",
+					HttpClient:  &http.Client{Transport: rt},
+				},
+			}
+
+			data := NvidiaCloudFunctionResourceModel{
+				Id:                       types.StringUnknown(),
+				FunctionID:               types.StringValue("mock-function-id"),
+				VersionID:                types.StringValue("mock-version-id"),
+				FunctionName:             types.StringValue("mock-function"),
+				ContainerImage:           types.StringValue(tt.containerImage),
+				Tags:                     types.SetValueMust(types.StringType, tt.tags),
+				AuthorizedParties:        types.SetValueMust(authorizedPartiesSchema().NestedObject.Type(), nil),
+				DeploymentSpecifications: tt.deploymentSpecifications,
+			}
+
+			var diags diag.Diagnostics
+			r.adoptVersion(context.Background(), &data, &diags)
+
+			if tt.wantErr != "" {
+				assert.True(t, diags.HasError())
+				assert.Equal(t, tt.wantErr, diags.Errors()[0].Summary())
+				return
+			}
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, types.StringValue("mock-function-id"), data.Id)
+			assert.Equal(t, types.StringValue("mock-version-id"), data.VersionID)
+			assert.Equal(t, types.StringValue("mock-deployment-id"), data.DeploymentID)
+			// The version is only read, nothing is created.
+			assert.Equal(t, []string{"GET " + versionPath, "GET " + deploymentPath, "GET " + authorizationPath}, rt.requests)
+		})
+	}
+}
+
+func TestValidateVersionID(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+	validateVersionID(NvidiaCloudFunctionResourceModel{FunctionID: types.StringValue("mock-function-id"), VersionID: types.StringValue("mock-version-id")}, &diags)
+	validateVersionID(NvidiaCloudFunctionResourceModel{FunctionID: types.StringNull(), VersionID: types.StringNull()}, &diags)
+	assert.False(t, diags.HasError(), diags)
+
+	validateVersionID(NvidiaCloudFunctionResourceModel{FunctionID: types.StringNull(), VersionID: types.StringValue("mock-version-id")}, &diags)
+	assert.True(t, diags.HasError())
+}
+
+func TestValidateMaxDeploymentWait(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+	validateMaxDeploymentWait(NvidiaCloudFunctionResourceModel{MaxDeploymentWait: types.StringValue("10m")}, &diags)
+	validateMaxDeploymentWait(NvidiaCloudFunctionResourceModel{MaxDeploymentWait: types.StringNull()}, &diags)
+	assert.False(t, diags.HasError(), diags)
+
+	for _, v := range []string{"10", "0s", "-5m"} {
+		diags = nil
+		validateMaxDeploymentWait(NvidiaCloudFunctionResourceModel{MaxDeploymentWait: types.StringValue(v)}, &diags)
+		assert.True(t, diags.HasError(), v)
+	}
+}
+
+func TestClearDeletedDeployment(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	specs := deploymentSpecificationsSet(ctx, []utils.NvidiaCloudFunctionDeploymentSpecification{
+		{Gpu: "L40", InstanceType: "gl40_1.br20_2xlarge", MinInstances: 1, MaxInstances: 1, MaxRequestConcurrency: 1},
+	}, &diag.Diagnostics{})
+	noSpecs := types.SetNull(deploymentSpecificationsSchema().NestedObject.Type())
+
+	tests := []struct {
+		name       string
+		specs      types.Set
+		deployment utils.NvidiaCloudFunctionDeployment
+		wantSpecs  int
+	}{
+		{
+			name:       "Deployed",
+			specs:      specs,
+			deployment: utils.NvidiaCloudFunctionDeployment{DeploymentID: "mock-deployment-id", FunctionStatus: "ACTIVE"},
+			wantSpecs:  1,
+		},
+		{
+			name:       "DeletedExternally",
+			specs:      specs,
+			deployment: utils.NvidiaCloudFunctionDeployment{},
+			wantSpecs:  0,
+		},
+		{
+			name:       "NeverDeployed",
+			specs:      noSpecs,
+			deployment: utils.NvidiaCloudFunctionDeployment{},
+			wantSpecs:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := NvidiaCloudFunctionResourceModel{DeploymentSpecifications: tt.specs}
+			clearDeletedDeployment(ctx, &data, &tt.deployment)
+
+			assert.Len(t, data.DeploymentSpecifications.Elements(), tt.wantSpecs)
+			if tt.wantSpecs == 0 && !tt.specs.IsNull() {
+				// The configured specifications now differ from the state, the plan deploys them again.
+				assert.False(t, data.DeploymentSpecifications.IsNull())
+				assert.False(t, data.DeploymentSpecifications.Equal(tt.specs))
+			}
+		})
+	}
+}
+
+func TestDeleteExistingDeployment(t *testing.T) {
+	t.Parallel()
+
+	deploymentPath := "/v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-id"
+
+	tests := []struct {
+		name         string
+		routes       map[string]*http.Response
+		wantRequests []string
+	}{
+		{
+			name: "Active",
+			routes: map[string]*http.Response{
+				"GET " + deploymentPath:    mockJsonResponse(http.StatusOK, `{"deployment": {"deploymentId": "mock-deployment-id", "functionStatus": "ACTIVE"}}`),
+				"DELETE " + deploymentPath: mockJsonResponse(http.StatusOK, `{}`),
+			},
+			wantRequests: []string{"GET " + deploymentPath, "DELETE " + deploymentPath, "GET " + deploymentPath},
+		},
+		{
+			name:         "NotDeployed",
+			routes:       map[string]*http.Response{},
+			wantRequests: []string{"GET " + deploymentPath},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := &routingRoundTripper{routes: tt.routes}
+			r := &NvidiaCloudFunctionResource{
+				client: &utils.NVCFClient{
+					NgcEndpoint: "https://api.ngc.nvidia.com",
+					NgcApiKey:   "mock-api-key",
+					NgcOrg:      "mock-org",
+					HttpClient:  &http.Client{Transport: rt},
+				},
+			}
+
+			var diags diag.Diagnostics
+			r.deleteExistingDeployment(context.Background(), "mock-function-id", "mock-version-id", &diags)
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, tt.wantRequests, rt.requests)
+		})
+	}
+}
+
+func TestValidateReservedTagPrefixes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		tags       []attr.Value
+		wantErrors int
+	}{
+		{
+			name: "UserTags",
+			tags: []attr.Value{types.StringValue("team:inference"), types.StringValue("managed_by:terraform")},
+		},
+		{
+			name:       "VersionLabel",
+			tags:       []attr.Value{types.StringValue(VERSION_LABEL_TAG_PREFIX + "canary")},
+			wantErrors: 1,
+		},
+		{
+			name:       "ProviderVersion",
+			tags:       []attr.Value{types.StringValue(PROVIDER_VERSION_TAG_PREFIX + "1.2.0"), types.StringValue("team:inference")},
+			wantErrors: 1,
+		},
+		{
+			name: "UnknownTag",
+			tags: []attr.Value{types.StringUnknown()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			validateReservedTagPrefixes(NvidiaCloudFunctionResourceModel{Tags: types.SetValueMust(types.StringType, tt.tags)}, &diags)
+
+			assert.Equal(t, tt.wantErrors, diags.ErrorsCount(), diags)
+			for _, d := range diags.Errors() {
+				assert.Equal(t, "Reserved Tag Prefix", d.Summary())
+			}
+		})
+	}
+}
+
+func TestAddDeploymentSpecCountWarning(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	spec := func(gpu string, maxInstances int64) NvidiaCloudFunctionResourceDeploymentSpecificationModel {
+		return NvidiaCloudFunctionResourceDeploymentSpecificationModel{
+			GpuSpecificationID:    types.StringUnknown(),
+			GpuType:               types.StringValue(gpu),
+			Backend:               types.StringValue("GFN"),
+			MaxInstances:          types.Int64Value(maxInstances),
+			MinInstances:          types.Int64Value(1),
+			MaxRequestConcurrency: types.Int64Value(1),
+			Configuration:         types.StringNull(),
+			InstanceType:          types.StringValue(gpu + "_instance"),
+			Clusters:              types.SetNull(types.StringType),
+			Regions:               types.SetNull(types.StringType),
+		}
+	}
+	specs := func(models ...NvidiaCloudFunctionResourceDeploymentSpecificationModel) types.Set {
+		set, d := types.SetValueFrom(ctx, deploymentSpecificationsSchema().NestedObject.Type(), models)
+		assert.False(t, d.HasError(), d)
+		return set
+	}
+
+	tests := []struct {
+		name        string
+		state       types.Set
+		plan        types.Set
+		wantWarning string
+	}{
+		{
+			name:        "Added",
+			state:       specs(spec("L40", 2)),
+			plan:        specs(spec("L40", 2), spec("H100", 3)),
+			wantWarning: "The deployment specifications change from 1 to 2, the total max_instances from 2 to 5.",
+		},
+		{
+			name:        "Removed",
+			state:       specs(spec("L40", 2)),
+			plan:        types.SetNull(deploymentSpecificationsSchema().NestedObject.Type()),
+			wantWarning: "The deployment specifications change from 1 to 0, the total max_instances from 2 to 0.",
+		},
+		{
+			name:  "SameCount",
+			state: specs(spec("L40", 2)),
+			plan:  specs(spec("L40", 4)),
+		},
+		{
+			name:  "Unknown",
+			state: specs(spec("L40", 2)),
+			plan:  types.SetUnknown(deploymentSpecificationsSchema().NestedObject.Type()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			addDeploymentSpecCountWarning(ctx, tt.state, tt.plan, &diags)
+
+			assert.False(t, diags.HasError(), diags)
+			if tt.wantWarning == "" {
+				assert.Empty(t, diags)
+				return
+			}
+			assert.Equal(t, 1, diags.WarningsCount())
+			assert.Equal(t, "Deployment Specification Count Changed", diags.Warnings()[0].Summary())
+			assert.Equal(t, tt.wantWarning, diags.Warnings()[0].Detail())
+		})
+	}
+}
+
+func TestValidatePorts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	healthModel := &NvidiaCloudFunctionResourceHealthModel{}
+	health := func(port int64) types.Object {
+		return types.ObjectValueMust(healthModel.attrTypes(), map[string]attr.Value{
+			"protocol":             types.StringValue("HTTP"),
+			"uri":                  types.StringValue("/health"),
+			"port":                 types.Int64Value(port),
+			"timeout":              types.StringValue("PT10S"),
+			"expected_status_code": types.Int64Value(200),
+		})
+	}
+
+	tests := []struct {
+		name          string
+		inferencePort types.Int64
+		health        types.Object
+		wantErrPath   string
+	}{
+		{
+			name:          "Valid",
+			inferencePort: types.Int64Value(8000),
+			health:        health(8001),
+		},
+		{
+			name:          "NotSet",
+			inferencePort: types.Int64Null(),
+			health:        types.ObjectNull(healthModel.attrTypes()),
+		},
+		{
+			name:          "InferencePortOutOfRange",
+			inferencePort: types.Int64Value(70000),
+			health:        types.ObjectNull(healthModel.attrTypes()),
+			wantErrPath:   "inference_port",
+		},
+		{
+			name:          "HealthPortOutOfRange",
+			inferencePort: types.Int64Value(8000),
+			health:        health(0),
+			wantErrPath:   "health.port",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			validatePorts(ctx, NvidiaCloudFunctionResourceModel{InferencePort: tt.inferencePort, Health: tt.health}, &diags)
+
+			if tt.wantErrPath == "" {
+				assert.False(t, diags.HasError(), diags)
+				return
+			}
+			assert.Equal(t, 1, diags.ErrorsCount(), diags)
+			assert.Equal(t, tt.wantErrPath, diags.Errors()[0].(diag.DiagnosticWithPath).Path().String())
+		})
+	}
+}
+
+func TestUpdateNvidiaCloudFunctionResourceModel_Secrets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &NvidiaCloudFunctionResource{}
+	secretType := secretsSchema().NestedObject.Type()
+	secret := func(name string, value string) attr.Value {
+		return types.ObjectValueMust(secretType.(types.ObjectType).AttrTypes, map[string]attr.Value{
+			"name":  types.StringValue(name),
+			"value": types.StringValue(value),
+		})
+	}
+	configured := types.SetValueMust(secretType, []attr.Value{secret("mock-secret-1", "mock-value-1"), secret("mock-secret-2", `{"key": "mock-value-2"}`)})
+
+	tests := []struct {
+		name        string
+		secretNames []string
+		want        types.Set
+	}{
+		{
+			name:        "ValuesKept",
+			secretNames: []string{"mock-secret-1", "mock-secret-2"},
+			want:        configured,
+		},
+		{
+			name:        "RemovedOutOfBand",
+			secretNames: []string{"mock-secret-1"},
+			want:        types.SetValueMust(secretType, []attr.Value{secret("mock-secret-1", "mock-value-1")}),
+		},
+		{
+			name:        "AllRemovedOutOfBand",
+			secretNames: []string{},
+			want:        types.SetValueMust(secretType, []attr.Value{}),
+		},
+		{
+			name: "NoNamesReturned",
+			want: types.SetValueMust(secretType, []attr.Value{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			data := NvidiaCloudFunctionResourceModel{Secrets: configured, Tags: types.SetNull(types.StringType)}
+			r.updateNvidiaCloudFunctionResourceModelBaseOnResponse(ctx, &diags, &data, &utils.NvidiaCloudFunctionInfo{Secrets: tt.secretNames}, nil, nil)
+
+			assert.False(t, diags.HasError(), diags)
+			assert.True(t, tt.want.Equal(data.Secrets), data.Secrets)
+		})
+	}
+}
@@ -16,12 +16,14 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &NvidiaCloudFunctionTelemetryDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &NvidiaCloudFunctionTelemetryDataSource{}
 
 func NewNvidiaCloudFunctionTelemetryDataSource() datasource.DataSource {
 	return &NvidiaCloudFunctionTelemetryDataSource{}
@@ -52,12 +54,14 @@ func (d *NvidiaCloudFunctionTelemetryDataSource) Schema(ctx context.Context, req
 		MarkdownDescription: "NVIDIA Cloud Function Telemetry Data Source",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Unique telemetry ID",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Unique telemetry ID. Either `id` or `name` must be set.",
 			},
 			"name": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Telemetry name",
+				MarkdownDescription: "Telemetry name. Either `id` or `name` must be set, the lookup fails when several telemetries share the name.",
 			},
 			"endpoint": schema.StringAttribute{
 				Computed:            true,
@@ -69,7 +73,7 @@ func (d *NvidiaCloudFunctionTelemetryDataSource) Schema(ctx context.Context, req
 			},
 			"telemetry_provider": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Telemetry provider (PROMETHEUS, GRAFANA_CLOUD, SPLUNK, DATADOG, SERVICENOW, KRATOS, KRATOS_THANOS, AZURE_MONITOR)",
+				MarkdownDescription: telemetryProviderDescription,
 			},
 			"types": schema.SetAttribute{
 				ElementType:         types.StringType,
@@ -84,6 +88,28 @@ func (d *NvidiaCloudFunctionTelemetryDataSource) Schema(ctx context.Context, req
 	}
 }
 
+func (d *NvidiaCloudFunctionTelemetryDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data NvidiaCloudFunctionTelemetryDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.IsUnknown() || data.Name.IsUnknown() {
+		return
+	}
+
+	if data.Id.IsNull() == data.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Telemetry Lookup",
+			"Exactly one of id or name must be set.",
+		)
+	}
+}
+
 func (d *NvidiaCloudFunctionTelemetryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -113,18 +139,33 @@ func (d *NvidiaCloudFunctionTelemetryDataSource) Read(ctx context.Context, req d
 		return
 	}
 
-	// Get the telemetry using the client
-	telemetryResponse, err := d.client.GetTelemetry(ctx, data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to read telemetry",
-			err.Error(),
-		)
-		return
+	var telemetry *utils.NvidiaCloudFunctionTelemetry
+
+	if data.Id.IsNull() {
+		var err error
+		telemetry, err = d.client.FindTelemetryByName(ctx, data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to find telemetry by name",
+				err.Error(),
+			)
+			return
+		}
+	} else {
+		// Get the telemetry using the client
+		telemetryResponse, err := d.client.GetTelemetry(ctx, data.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to read telemetry",
+				err.Error(),
+			)
+			return
+		}
+		telemetry = &telemetryResponse.Telemetry
 	}
 
 	// Update the model with the response
-	d.updateTelemetryDataSourceModel(ctx, &data, &telemetryResponse.Telemetry)
+	d.updateTelemetryDataSourceModel(ctx, &data, telemetry)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -15,6 +15,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -76,3 +77,43 @@ func TestAccCloudFunctionTelemetryDataSource_Success(t *testing.T) {
 		},
 	})
 }
+
+func TestAccCloudFunctionTelemetryDataSource_LookupByName(t *testing.T) {
+	var telemetryDatasourceName = testutils.TestCommonPrefix + "telemetry-datasource-by-name"
+	var testCloudFunctionTelemetryResourceFullPath = fmt.Sprintf("ngc_cloud_function_telemetry.%s", telemetryDatasourceName)
+	var testCloudFunctionTelemetryDatasourceByNameFullPath = fmt.Sprintf("data.ngc_cloud_function_telemetry.%s", telemetryDatasourceName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: generateTelemetryResourceConfig(telemetryDatasourceName, TELEMETRY_TYPES) + fmt.Sprintf(`
+					data "ngc_cloud_function_telemetry" "%s" {
+						name = ngc_cloud_function_telemetry.%s.name
+					}
+				`, telemetryDatasourceName, telemetryDatasourceName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(testCloudFunctionTelemetryDatasourceByNameFullPath, "name", telemetryDatasourceName),
+					resource.TestCheckResourceAttrPair(testCloudFunctionTelemetryResourceFullPath, "id", testCloudFunctionTelemetryDatasourceByNameFullPath, "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudFunctionTelemetryDataSource_LookupWithoutIdAndNameFail(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "ngc_cloud_function_telemetry" "%s" {
+					}
+				`, testCloudFunctionTelemetryDatasourceName),
+				ExpectError: regexp.MustCompile("Exactly one of id or name must be set"),
+			},
+		},
+	})
+}
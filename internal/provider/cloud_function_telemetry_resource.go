@@ -14,8 +14,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -32,6 +34,37 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NvidiaCloudFunctionTelemetryResource{}
 var _ resource.ResourceWithImportState = &NvidiaCloudFunctionTelemetryResource{}
+var _ resource.ResourceWithValidateConfig = &NvidiaCloudFunctionTelemetryResource{}
+
+// Import identifiers with this prefix are resolved by telemetry name, e.g. "name:my-telemetry".
+const TELEMETRY_IMPORT_NAME_PREFIX = "name:"
+
+// telemetryProviderRequirement lists the protocols and telemetry types an exporter of a telemetry provider supports.
+type telemetryProviderRequirement struct {
+	protocols []string
+	types     []string
+}
+
+// telemetryProviders are the telemetry providers documented by both the telemetry resource and data source.
+// The resource doesn't restrict the provider to them, a provider NVCF adds later is passed through as is.
+var telemetryProviders = []string{"PROMETHEUS", "GRAFANA_CLOUD", "SPLUNK", "DATADOG", "SERVICENOW", "KRATOS", "KRATOS_THANOS", "AZURE_MONITOR"}
+
+var telemetryProviderDescription = fmt.Sprintf("Telemetry provider (%s)", strings.Join(telemetryProviders, ", "))
+
+// supportedTelemetryTypes are the telemetry data types NVCF can export.
+var supportedTelemetryTypes = []string{"LOGS", "METRICS", "TRACES"}
+
+// telemetryProviderRequirements is keyed by telemetry provider, providers missing here aren't validated.
+var telemetryProviderRequirements = map[string]telemetryProviderRequirement{
+	"PROMETHEUS":    {protocols: []string{"HTTP"}, types: []string{"METRICS"}},
+	"GRAFANA_CLOUD": {protocols: []string{"HTTP"}, types: []string{"LOGS", "METRICS", "TRACES"}},
+	"SPLUNK":        {protocols: []string{"HTTP"}, types: []string{"LOGS", "METRICS", "TRACES"}},
+	"DATADOG":       {protocols: []string{"HTTP"}, types: []string{"LOGS", "METRICS", "TRACES"}},
+	"SERVICENOW":    {protocols: []string{"GRPC"}, types: []string{"LOGS", "METRICS", "TRACES"}},
+	"KRATOS":        {protocols: []string{"HTTP", "GRPC"}, types: []string{"LOGS", "METRICS", "TRACES"}},
+	"KRATOS_THANOS": {protocols: []string{"HTTP"}, types: []string{"METRICS"}},
+	"AZURE_MONITOR": {protocols: []string{"HTTP"}, types: []string{"LOGS", "METRICS", "TRACES"}},
+}
 
 func NewNvidiaCloudFunctionTelemetryResource() resource.Resource {
 	return &NvidiaCloudFunctionTelemetryResource{}
@@ -74,7 +107,7 @@ func (r *NvidiaCloudFunctionTelemetryResource) Schema(ctx context.Context, req r
 			},
 			"telemetry_provider": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Telemetry provider (PROMETHEUS, GRAFANA_CLOUD, SPLUNK, DATADOG, SERVICENOW, KRATOS, KRATOS_THANOS, AZURE_MONITOR)",
+				MarkdownDescription: telemetryProviderDescription,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -82,7 +115,7 @@ func (r *NvidiaCloudFunctionTelemetryResource) Schema(ctx context.Context, req r
 			"types": schema.SetAttribute{
 				ElementType:         types.StringType,
 				Required:            true,
-				MarkdownDescription: "Set of telemetry data types (LOGS, METRICS, TRACES)",
+				MarkdownDescription: fmt.Sprintf("Set of telemetry data types (%s), at least one", strings.Join(supportedTelemetryTypes, ", ")),
 				PlanModifiers: []planmodifier.Set{
 					setplanmodifier.RequiresReplace(),
 				},
@@ -102,7 +135,14 @@ func (r *NvidiaCloudFunctionTelemetryResource) Schema(ctx context.Context, req r
 					},
 				},
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.RequiresReplace(),
+					// NVCF never returns the secret, an imported telemetry adopts the configured one instead of being replaced.
+					objectplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing the secret replaces the telemetry, unless it was imported without one.",
+						"Changing the secret replaces the telemetry, unless it was imported without one.",
+					),
 				},
 			},
 			"created_at": schema.StringAttribute{
@@ -113,6 +153,93 @@ func (r *NvidiaCloudFunctionTelemetryResource) Schema(ctx context.Context, req r
 	}
 }
 
+func (r *NvidiaCloudFunctionTelemetryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NvidiaCloudFunctionTelemetryResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Types.IsUnknown() {
+		return
+	}
+
+	var telemetryTypes []string
+	resp.Diagnostics.Append(data.Types.ElementsAs(ctx, &telemetryTypes, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateTelemetryTypes(telemetryTypes, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() || data.Provider.IsUnknown() || data.Protocol.IsUnknown() {
+		return
+	}
+
+	validateTelemetryProviderRequirements(data.Provider.ValueString(), data.Protocol.ValueString(), telemetryTypes, &resp.Diagnostics)
+}
+
+// validateTelemetryTypes ensures the telemetry types are distinct supported values, and that there's at least one.
+func validateTelemetryTypes(telemetryTypes []string, diag *diag.Diagnostics) {
+	if len(telemetryTypes) == 0 {
+		diag.AddAttributeError(
+			path.Root("types"),
+			"Missing Telemetry Type",
+			fmt.Sprintf("At least one telemetry type is required, valid values are %s.", strings.Join(supportedTelemetryTypes, ", ")),
+		)
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, telemetryType := range telemetryTypes {
+		if seen[telemetryType] {
+			diag.AddAttributeError(
+				path.Root("types"),
+				"Duplicate Telemetry Type",
+				fmt.Sprintf("The %s telemetry type is set more than once.", telemetryType),
+			)
+		}
+		seen[telemetryType] = true
+
+		if !slices.Contains(supportedTelemetryTypes, telemetryType) {
+			diag.AddAttributeError(
+				path.Root("types"),
+				"Unsupported Telemetry Type",
+				fmt.Sprintf("Valid telemetry types are %s, got: %s", strings.Join(supportedTelemetryTypes, ", "), telemetryType),
+			)
+		}
+	}
+}
+
+// validateTelemetryProviderRequirements ensures the protocol and the telemetry types are supported by the telemetry provider.
+func validateTelemetryProviderRequirements(provider string, protocol string, telemetryTypes []string, diag *diag.Diagnostics) {
+	requirement, ok := telemetryProviderRequirements[provider]
+	if !ok {
+		return
+	}
+
+	if !slices.Contains(requirement.protocols, protocol) {
+		diag.AddAttributeError(
+			path.Root("protocol"),
+			"Unsupported Telemetry Protocol",
+			fmt.Sprintf("The %s telemetry provider supports the %s protocol(s), got: %s", provider, strings.Join(requirement.protocols, ", "), protocol),
+		)
+	}
+
+	for _, telemetryType := range telemetryTypes {
+		if !slices.Contains(requirement.types, telemetryType) {
+			diag.AddAttributeError(
+				path.Root("types"),
+				"Unsupported Telemetry Type",
+				fmt.Sprintf("The %s telemetry provider supports the %s telemetry type(s), got: %s", provider, strings.Join(requirement.types, ", "), telemetryType),
+			)
+		}
+	}
+}
+
 func (r *NvidiaCloudFunctionTelemetryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -245,6 +372,24 @@ func (r *NvidiaCloudFunctionTelemetryResource) Read(ctx context.Context, req res
 }
 
 func (r *NvidiaCloudFunctionTelemetryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NvidiaCloudFunctionTelemetryResourceModel
+	var state NvidiaCloudFunctionTelemetryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An imported telemetry has no secret in state, the configured one is kept without any API call.
+	if state.Secret.IsNull() && data.Endpoint.Equal(state.Endpoint) {
+		data.Name = state.Name
+		data.CreatedAt = state.CreatedAt
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	resp.Diagnostics.AddError(
 		"Update not supported - implementation error.",
 		"Telemetry APIs do not support updates. You should make sure all the changes will trigger force-replaced.",
@@ -273,8 +418,23 @@ func (r *NvidiaCloudFunctionTelemetryResource) Delete(ctx context.Context, req r
 }
 
 func (r *NvidiaCloudFunctionTelemetryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	telemetryId := req.ID
+
+	// Import by telemetry name, resolving it to the telemetry ID
+	if name, ok := strings.CutPrefix(req.ID, TELEMETRY_IMPORT_NAME_PREFIX); ok {
+		telemetry, err := r.client.FindTelemetryByName(ctx, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to find telemetry by name",
+				err.Error(),
+			)
+			return
+		}
+		telemetryId = telemetry.TelemetryId
+	}
+
 	// Import by telemetry ID
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), telemetryId)...)
 }
 
 // updateTelemetryResourceModel updates the Terraform model with data from the API response.
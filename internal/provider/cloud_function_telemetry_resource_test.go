@@ -119,6 +119,16 @@ func TestAccCloudFunctionTelemetryResource_CreateAndUpdateAndDeleteTelemetrySucc
 					"secret",
 				},
 			},
+			// Verify Telemetry Import by name
+			{
+				ResourceName:      testCloudFunctionTelemetryResourceFullPath,
+				ImportStateId:     TELEMETRY_IMPORT_NAME_PREFIX + telemetryName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"secret",
+				},
+			},
 		},
 	})
 }
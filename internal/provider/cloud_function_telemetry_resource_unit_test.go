@@ -0,0 +1,190 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestValidateTelemetryProviderRequirements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		provider       string
+		protocol       string
+		telemetryTypes []string
+		wantErrors     int
+	}{
+		{name: "PrometheusHttpMetrics", provider: "PROMETHEUS", protocol: "HTTP", telemetryTypes: []string{"METRICS"}},
+		{name: "PrometheusGrpc", provider: "PROMETHEUS", protocol: "GRPC", telemetryTypes: []string{"METRICS"}, wantErrors: 1},
+		{name: "PrometheusLogs", provider: "PROMETHEUS", protocol: "HTTP", telemetryTypes: []string{"METRICS", "LOGS"}, wantErrors: 1},
+		{name: "GrafanaCloudHttp", provider: "GRAFANA_CLOUD", protocol: "HTTP", telemetryTypes: []string{"LOGS", "METRICS", "TRACES"}},
+		{name: "GrafanaCloudGrpc", provider: "GRAFANA_CLOUD", protocol: "GRPC", telemetryTypes: []string{"LOGS"}, wantErrors: 1},
+		{name: "SplunkHttp", provider: "SPLUNK", protocol: "HTTP", telemetryTypes: []string{"LOGS", "TRACES"}},
+		{name: "SplunkGrpc", provider: "SPLUNK", protocol: "GRPC", telemetryTypes: []string{"LOGS"}, wantErrors: 1},
+		{name: "DatadogHttp", provider: "DATADOG", protocol: "HTTP", telemetryTypes: []string{"METRICS", "TRACES"}},
+		{name: "DatadogGrpc", provider: "DATADOG", protocol: "GRPC", telemetryTypes: []string{"METRICS"}, wantErrors: 1},
+		{name: "ServiceNowGrpc", provider: "SERVICENOW", protocol: "GRPC", telemetryTypes: []string{"LOGS", "METRICS", "TRACES"}},
+		{name: "ServiceNowHttp", provider: "SERVICENOW", protocol: "HTTP", telemetryTypes: []string{"TRACES"}, wantErrors: 1},
+		{name: "KratosHttp", provider: "KRATOS", protocol: "HTTP", telemetryTypes: []string{"LOGS"}},
+		{name: "KratosGrpc", provider: "KRATOS", protocol: "GRPC", telemetryTypes: []string{"TRACES"}},
+		{name: "KratosThanosHttpMetrics", provider: "KRATOS_THANOS", protocol: "HTTP", telemetryTypes: []string{"METRICS"}},
+		{name: "KratosThanosGrpcTraces", provider: "KRATOS_THANOS", protocol: "GRPC", telemetryTypes: []string{"TRACES"}, wantErrors: 2},
+		{name: "AzureMonitorHttp", provider: "AZURE_MONITOR", protocol: "HTTP", telemetryTypes: []string{"LOGS", "METRICS"}},
+		{name: "AzureMonitorGrpc", provider: "AZURE_MONITOR", protocol: "GRPC", telemetryTypes: []string{"LOGS"}, wantErrors: 1},
+		{name: "UnknownProviderNotValidated", provider: "MOCK_PROVIDER", protocol: "GRPC", telemetryTypes: []string{"LOGS"}},
+		{name: "VictoriaMetricsAccepted", provider: "VICTORIAMETRICS", protocol: "HTTP", telemetryTypes: []string{"METRICS"}},
+		{name: "TimestreamAccepted", provider: "TIMESTREAM", protocol: "GRPC", telemetryTypes: []string{"METRICS"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			validateTelemetryProviderRequirements(tt.provider, tt.protocol, tt.telemetryTypes, &diags)
+
+			assert.Equal(t, tt.wantErrors, diags.ErrorsCount(), diags)
+		})
+	}
+}
+
+func TestValidateTelemetryTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		telemetryTypes []string
+		wantSummaries  []string
+	}{
+		{name: "AllSupported", telemetryTypes: []string{"LOGS", "METRICS", "TRACES"}},
+		{name: "Empty", telemetryTypes: []string{}, wantSummaries: []string{"Missing Telemetry Type"}},
+		{name: "Duplicate", telemetryTypes: []string{"LOGS", "LOGS"}, wantSummaries: []string{"Duplicate Telemetry Type"}},
+		{name: "Unsupported", telemetryTypes: []string{"LOGS", "EVENTS"}, wantSummaries: []string{"Unsupported Telemetry Type"}},
+		{name: "Lowercase", telemetryTypes: []string{"logs"}, wantSummaries: []string{"Unsupported Telemetry Type"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateTelemetryTypes(tt.telemetryTypes, &diags)
+
+			var summaries []string
+			for _, d := range diags.Errors() {
+				summaries = append(summaries, d.Summary())
+			}
+			assert.Equal(t, tt.wantSummaries, summaries)
+		})
+	}
+
+	// The message lists the valid values.
+	var diags diag.Diagnostics
+	validateTelemetryTypes([]string{"EVENTS"}, &diags)
+	assert.Equal(t, "Valid telemetry types are LOGS, METRICS, TRACES, got: EVENTS", diags.Errors()[0].Detail())
+}
+
+func TestTelemetryProviders(t *testing.T) {
+	t.Parallel()
+
+	// Every provider with known requirements is documented by the resource and the data source.
+	for provider := range telemetryProviderRequirements {
+		assert.Contains(t, telemetryProviders, provider)
+	}
+	assert.Len(t, telemetryProviders, len(telemetryProviderRequirements))
+}
+
+func TestNvidiaCloudFunctionTelemetryResourceImport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rt := &routingRoundTripper{routes: map[string]*http.Response{
+		"GET /v2/orgs/mock-org/nvcf/telemetries/mock-telemetry-id": mockJsonResponse(http.StatusOK,
+			`{"telemetry":{"telemetryId":"mock-telemetry-id","name":"mock-telemetry","endpoint":"https://mock-endpoint.nvidia.com/otlp",`+
+				`"protocol":"HTTP","provider":"GRAFANA_CLOUD","types":["LOGS","METRICS"],"createdAt":"2024-01-01T00:00:00Z"}}`),
+	}}
+	r := &NvidiaCloudFunctionTelemetryResource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+	importResp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaType, nil),
+		},
+	}
+
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "mock-telemetry-id"}, importResp)
+	assert.False(t, importResp.Diagnostics.HasError(), importResp.Diagnostics)
+
+	// The import only sets the ID, the refresh following it fills the rest.
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	var data NvidiaCloudFunctionTelemetryResourceModel
+	assert.False(t, readResp.State.Get(ctx, &data).HasError())
+
+	var telemetryTypes []string
+	assert.False(t, data.Types.ElementsAs(ctx, &telemetryTypes, false).HasError())
+
+	assert.Equal(t, "mock-telemetry-id", data.Id.ValueString())
+	assert.Equal(t, "mock-telemetry", data.Name.ValueString())
+	assert.Equal(t, "https://mock-endpoint.nvidia.com/otlp", data.Endpoint.ValueString())
+	assert.Equal(t, "HTTP", data.Protocol.ValueString())
+	assert.Equal(t, "GRAFANA_CLOUD", data.Provider.ValueString())
+	assert.ElementsMatch(t, []string{"LOGS", "METRICS"}, telemetryTypes)
+	assert.Equal(t, "2024-01-01T00:00:00Z", data.CreatedAt.ValueString())
+	// NVCF never returns the secret.
+	assert.True(t, data.Secret.IsNull())
+
+	// The configured secret is then adopted in place.
+	plan := tfsdk.Plan{Schema: readResp.State.Schema, Raw: readResp.State.Raw}
+	secret := types.ObjectValueMust(
+		map[string]attr.Type{"name": types.StringType, "value": types.StringType},
+		map[string]attr.Value{"name": types.StringValue("mock-telemetry"), "value": types.StringValue("mock-secret-value")},
+	)
+	assert.False(t, plan.SetAttribute(ctx, path.Root("secret"), secret).HasError())
+	assert.False(t, plan.SetAttribute(ctx, path.Root("name"), types.StringUnknown()).HasError())
+	assert.False(t, plan.SetAttribute(ctx, path.Root("created_at"), types.StringUnknown()).HasError())
+
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	assert.False(t, updateResp.Diagnostics.HasError(), updateResp.Diagnostics)
+
+	assert.False(t, updateResp.State.Get(ctx, &data).HasError())
+	assert.True(t, data.Secret.Equal(secret))
+	assert.Equal(t, "mock-telemetry", data.Name.ValueString())
+	assert.Equal(t, "2024-01-01T00:00:00Z", data.CreatedAt.ValueString())
+	assert.Equal(t, []string{"GET /v2/orgs/mock-org/nvcf/telemetries/mock-telemetry-id"}, rt.requests)
+}
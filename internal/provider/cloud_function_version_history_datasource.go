@@ -0,0 +1,212 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NvidiaCloudFunctionVersionHistoryDataSource{}
+
+// versionHistoryPageSize is the number of versions asked for per page of the versions listing.
+const versionHistoryPageSize = 100
+
+func NewNvidiaCloudFunctionVersionHistoryDataSource() datasource.DataSource {
+	return &NvidiaCloudFunctionVersionHistoryDataSource{}
+}
+
+// NvidiaCloudFunctionVersionHistoryDataSource defines the data source implementation.
+type NvidiaCloudFunctionVersionHistoryDataSource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionVersionHistoryDataSourceModel describes the data source data model.
+type NvidiaCloudFunctionVersionHistoryDataSourceModel struct {
+	FunctionID types.String `tfsdk:"function_id"`
+	Versions   types.List   `tfsdk:"versions"`
+}
+
+// NvidiaCloudFunctionVersionHistoryVersionModel describes a single function version of the history.
+type NvidiaCloudFunctionVersionHistoryVersionModel struct {
+	VersionID           types.String `tfsdk:"version_id"`
+	Status              types.String `tfsdk:"status"`
+	CreatedAt           types.String `tfsdk:"created_at"`
+	HasActiveDeployment types.Bool   `tfsdk:"has_active_deployment"`
+}
+
+func (m *NvidiaCloudFunctionVersionHistoryVersionModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"version_id":            types.StringType,
+		"status":                types.StringType,
+		"created_at":            types.StringType,
+		"has_active_deployment": types.BoolType,
+	}
+}
+
+func (d *NvidiaCloudFunctionVersionHistoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_function_version_history"
+}
+
+func (d *NvidiaCloudFunctionVersionHistoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all versions of a function, newest first, along with whether each of them has an ACTIVE deployment. Meant for audit and rollback tooling.",
+		Attributes: map[string]schema.Attribute{
+			"function_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Function ID",
+			},
+			"versions": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Function Versions, newest first",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function Version ID",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function Version status, e.g. \"ACTIVE\"",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Creation time of the Function Version, in RFC 3339 format",
+						},
+						"has_active_deployment": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the Function Version has an ACTIVE deployment",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NvidiaCloudFunctionVersionHistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = ngcClient.NVCFClient()
+}
+
+func (d *NvidiaCloudFunctionVersionHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NvidiaCloudFunctionVersionHistoryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	functionID := data.FunctionID.ValueString()
+
+	functionVersions, err := d.listFunctionVersions(ctx, functionID)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to read Cloud Function versions",
+			err.Error(),
+		)
+		return
+	}
+
+	slices.SortStableFunc(functionVersions, func(a, b utils.NvidiaCloudFunctionInfo) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	versions := make([]NvidiaCloudFunctionVersionHistoryVersionModel, 0, len(functionVersions))
+	for _, f := range functionVersions {
+		hasActiveDeployment := false
+
+		// INACTIVE versions have no deployment, only the others are checked.
+		if f.Status != "INACTIVE" {
+			readNvidiaCloudFunctionDeploymentResponse, err := d.client.ReadNvidiaCloudFunctionDeployment(ctx, functionID, f.VersionID)
+
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Failed to read Cloud Function deployment",
+					err.Error(),
+				)
+				return
+			}
+
+			// NVCF answers an empty deployment for a version that isn't deployed.
+			hasActiveDeployment = readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus == "ACTIVE"
+		}
+
+		versions = append(versions, NvidiaCloudFunctionVersionHistoryVersionModel{
+			VersionID:           types.StringValue(f.VersionID),
+			Status:              types.StringValue(f.Status),
+			CreatedAt:           types.StringValue(f.CreatedAt.Format(time.RFC3339)),
+			HasActiveDeployment: types.BoolValue(hasActiveDeployment),
+		})
+	}
+
+	versionsList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: (&NvidiaCloudFunctionVersionHistoryVersionModel{}).attrTypes()}, versions)
+	resp.Diagnostics.Append(diags...)
+	data.Versions = versionsList
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listFunctionVersions pages through the versions of the function until a short page, a function
+// may have more versions than a single page holds.
+func (d *NvidiaCloudFunctionVersionHistoryDataSource) listFunctionVersions(ctx context.Context, functionID string) ([]utils.NvidiaCloudFunctionInfo, error) {
+	var functionVersions []utils.NvidiaCloudFunctionInfo
+	listed := make(map[string]bool)
+
+	for offset := 0; ; offset += versionHistoryPageSize {
+		listNvidiaCloudFunctionVersionsResponse, err := d.client.ListNvidiaCloudFunctionVersionsWithQuery(ctx, functionID, versionHistoryPageSize, offset)
+
+		if err != nil {
+			return nil, err
+		}
+
+		newVersions := 0
+		for _, f := range listNvidiaCloudFunctionVersionsResponse.Functions {
+			if !listed[f.VersionID] {
+				listed[f.VersionID] = true
+				functionVersions = append(functionVersions, f)
+				newVersions++
+			}
+		}
+
+		// A page without new versions means the listing isn't paged.
+		if len(listNvidiaCloudFunctionVersionsResponse.Functions) < versionHistoryPageSize || newVersions == 0 {
+			return functionVersions, nil
+		}
+	}
+}
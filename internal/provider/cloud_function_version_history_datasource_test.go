@@ -0,0 +1,66 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build !unittest
+// +build !unittest
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/testutils"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestAccCloudFunctionVersionHistoryDataSource_Success(t *testing.T) {
+	var datasourceName = testutils.TestCommonPrefix + "version-history-datasource"
+	var datasourceFullPath = fmt.Sprintf("data.ngc_cloud_function_version_history.%s", datasourceName)
+
+	functionInfo := testutils.CreateContainerFunction(t)
+	defer testutils.DeleteFunction(t, functionInfo.Function.ID, functionInfo.Function.VersionID)
+
+	newVersionInfo, err := testutils.TestNVCFClient.CreateNvidiaCloudFunction(testutils.Ctx, functionInfo.Function.ID, utils.CreateNvidiaCloudFunctionRequest{
+		FunctionName:   testutils.TestContainerFunctionName,
+		ContainerImage: testutils.TestContainerUri,
+		InferencePort:  testutils.TestContainerPort,
+		InferenceUrl:   testutils.TestContainerInferenceUrl,
+		HealthUri:      testutils.TestContainerHealthUri,
+		APIBodyFormat:  testutils.TestContainerAPIFormat,
+		FunctionType:   testutils.TestFunctionType,
+	})
+	if err != nil {
+		t.Fatalf("Unable to create function version: %s", err.Error())
+	}
+	defer testutils.DeleteFunction(t, newVersionInfo.Function.ID, newVersionInfo.Function.VersionID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "ngc_cloud_function_version_history" "%s" {
+						function_id = "%s"
+					}
+				`, datasourceName, functionInfo.Function.ID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceFullPath, "versions.#", "2"),
+					resource.TestCheckResourceAttr(datasourceFullPath, "versions.0.version_id", newVersionInfo.Function.VersionID),
+					resource.TestCheckResourceAttr(datasourceFullPath, "versions.0.has_active_deployment", "false"),
+					resource.TestCheckResourceAttr(datasourceFullPath, "versions.1.version_id", functionInfo.Function.VersionID),
+					resource.TestCheckResourceAttrSet(datasourceFullPath, "versions.1.created_at"),
+				),
+			},
+		},
+	})
+}
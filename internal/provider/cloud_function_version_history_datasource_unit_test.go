@@ -0,0 +1,164 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestNvidiaCloudFunctionVersionHistoryDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rt := &routingRoundTripper{routes: map[string]*http.Response{
+		"GET /v2/orgs/mock-org/nvcf/functions/mock-function-id/versions": mockJsonResponse(http.StatusOK,
+			`{"functions":[{"id":"mock-function-id","versionId":"mock-version-1","status":"INACTIVE","createdAt":"2024-01-01T00:00:00Z"},`+
+				`{"id":"mock-function-id","versionId":"mock-version-3","status":"ACTIVE","createdAt":"2024-03-01T00:00:00Z"},`+
+				`{"id":"mock-function-id","versionId":"mock-version-2","status":"DEPLOYING","createdAt":"2024-02-01T00:00:00Z"}]}`),
+		"GET /v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-3": mockJsonResponse(http.StatusOK,
+			`{"deployment":{"deploymentId":"mock-deployment-3","functionStatus":"ACTIVE"}}`),
+		"GET /v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-2": mockJsonResponse(http.StatusOK,
+			`{"deployment":{"deploymentId":"mock-deployment-2","functionStatus":"DEPLOYING"}}`),
+	}}
+	d := &NvidiaCloudFunctionVersionHistoryDataSource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"function_id": tftypes.NewValue(tftypes.String, "mock-function-id"),
+				"versions":    tftypes.NewValue(schemaType.AttributeTypes["versions"], nil),
+			}),
+		},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaType, nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data NvidiaCloudFunctionVersionHistoryDataSourceModel
+	assert.False(t, resp.State.Get(ctx, &data).HasError())
+
+	var versions []NvidiaCloudFunctionVersionHistoryVersionModel
+	assert.False(t, data.Versions.ElementsAs(ctx, &versions, false).HasError())
+
+	// Newest first.
+	assert.Equal(t, []NvidiaCloudFunctionVersionHistoryVersionModel{
+		{VersionID: types.StringValue("mock-version-3"), Status: types.StringValue("ACTIVE"), CreatedAt: types.StringValue("2024-03-01T00:00:00Z"), HasActiveDeployment: types.BoolValue(true)},
+		{VersionID: types.StringValue("mock-version-2"), Status: types.StringValue("DEPLOYING"), CreatedAt: types.StringValue("2024-02-01T00:00:00Z"), HasActiveDeployment: types.BoolValue(false)},
+		{VersionID: types.StringValue("mock-version-1"), Status: types.StringValue("INACTIVE"), CreatedAt: types.StringValue("2024-01-01T00:00:00Z"), HasActiveDeployment: types.BoolValue(false)},
+	}, versions)
+
+	// The deployment of the INACTIVE version isn't read.
+	assert.Equal(t, []string{
+		"GET /v2/orgs/mock-org/nvcf/functions/mock-function-id/versions",
+		"GET /v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-3",
+		"GET /v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-2",
+	}, rt.requests)
+}
+
+func TestNvidiaCloudFunctionVersionHistoryDataSourceRead_Pages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// The first page is full, so the second one is read as well.
+	firstPage := make([]string, 0, versionHistoryPageSize)
+	for i := 0; i < versionHistoryPageSize; i++ {
+		firstPage = append(firstPage, fmt.Sprintf(`{"id":"mock-function-id","versionId":"mock-version-%d","status":"INACTIVE","createdAt":"2024-01-01T00:00:00Z"}`, i))
+	}
+	versionsRoute := "GET /v2/orgs/mock-org/nvcf/functions/mock-function-id/versions"
+	rt := &routingRoundTripper{routes: map[string]*http.Response{
+		versionsRoute + fmt.Sprintf("?limit=%d&offset=0", versionHistoryPageSize): mockJsonResponse(http.StatusOK,
+			`{"functions":[`+strings.Join(firstPage, ",")+`]}`),
+		versionsRoute + fmt.Sprintf("?limit=%d&offset=%d", versionHistoryPageSize, versionHistoryPageSize): mockJsonResponse(http.StatusOK,
+			`{"functions":[{"id":"mock-function-id","versionId":"mock-version-new","status":"ACTIVE","createdAt":"2024-03-01T00:00:00Z"}]}`),
+		"GET /v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-new": mockJsonResponse(http.StatusOK,
+			`{"deployment":{"deploymentId":"mock-deployment-new","functionStatus":"ACTIVE"}}`),
+	}}
+	d := &NvidiaCloudFunctionVersionHistoryDataSource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"function_id": tftypes.NewValue(tftypes.String, "mock-function-id"),
+				"versions":    tftypes.NewValue(schemaType.AttributeTypes["versions"], nil),
+			}),
+		},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaType, nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data NvidiaCloudFunctionVersionHistoryDataSourceModel
+	assert.False(t, resp.State.Get(ctx, &data).HasError())
+
+	var versions []NvidiaCloudFunctionVersionHistoryVersionModel
+	assert.False(t, data.Versions.ElementsAs(ctx, &versions, false).HasError())
+
+	assert.Len(t, versions, versionHistoryPageSize+1)
+	assert.Equal(t, types.StringValue("mock-version-new"), versions[0].VersionID)
+	assert.Equal(t, []string{
+		versionsRoute,
+		versionsRoute,
+		"GET /v2/orgs/mock-org/nvcf/deployments/functions/mock-function-id/versions/mock-version-new",
+	}, rt.requests)
+}
@@ -0,0 +1,217 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NvidiaCloudFunctionsByIdDataSource{}
+
+func NewNvidiaCloudFunctionsByIdDataSource() datasource.DataSource {
+	return &NvidiaCloudFunctionsByIdDataSource{}
+}
+
+// NvidiaCloudFunctionsByIdDataSource defines the data source implementation.
+type NvidiaCloudFunctionsByIdDataSource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionsByIdDataSourceModel describes the data source data model.
+type NvidiaCloudFunctionsByIdDataSourceModel struct {
+	Ids       types.List `tfsdk:"ids"`
+	Functions types.Map  `tfsdk:"functions"`
+}
+
+// NvidiaCloudFunctionsByIdFunctionModel describes a single function version read by the data source.
+type NvidiaCloudFunctionsByIdFunctionModel struct {
+	FunctionID     types.String `tfsdk:"function_id"`
+	VersionID      types.String `tfsdk:"version_id"`
+	FunctionName   types.String `tfsdk:"function_name"`
+	Status         types.String `tfsdk:"status"`
+	FunctionType   types.String `tfsdk:"function_type"`
+	InferenceUrl   types.String `tfsdk:"inference_url"`
+	ContainerImage types.String `tfsdk:"container_image"`
+	HelmChart      types.String `tfsdk:"helm_chart"`
+	Description    types.String `tfsdk:"description"`
+}
+
+func (m *NvidiaCloudFunctionsByIdFunctionModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"function_id":     types.StringType,
+		"version_id":      types.StringType,
+		"function_name":   types.StringType,
+		"status":          types.StringType,
+		"function_type":   types.StringType,
+		"inference_url":   types.StringType,
+		"container_image": types.StringType,
+		"helm_chart":      types.StringType,
+		"description":     types.StringType,
+	}
+}
+
+func (d *NvidiaCloudFunctionsByIdDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_functions_by_id"
+}
+
+func (d *NvidiaCloudFunctionsByIdDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads many function versions at once. The versions of each function are listed only once, whatever the number of its versions requested. " +
+			"Entries which cannot be read are reported as warnings and left out of `functions`.",
+		Attributes: map[string]schema.Attribute{
+			"ids": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Function versions to read, in the `function_id,version_id` format",
+			},
+			"functions": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Function versions keyed by their entry in `ids`",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"function_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function ID",
+						},
+						"version_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function Version ID",
+						},
+						"function_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function name",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function Version status, e.g. \"ACTIVE\"",
+						},
+						"function_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function type",
+						},
+						"inference_url": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Service endpoint Path",
+						},
+						"container_image": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Container image. Empty for helm based functions.",
+						},
+						"helm_chart": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Helm chart. Empty for container based functions.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function description",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NvidiaCloudFunctionsByIdDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = ngcClient.NVCFClient()
+}
+
+func (d *NvidiaCloudFunctionsByIdDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NvidiaCloudFunctionsByIdDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []string
+	resp.Diagnostics.Append(data.Ids.ElementsAs(ctx, &ids, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Entries of the same function share a single versions listing.
+	ctx = utils.WithVersionsCache(ctx)
+
+	functions := map[string]NvidiaCloudFunctionsByIdFunctionModel{}
+	for i, id := range ids {
+		entryPath := path.Root("ids").AtListIndex(i)
+
+		functionID, versionID, err := parseFunctionImportID(id)
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(entryPath, "Invalid Function Version ID", err.Error())
+			continue
+		}
+
+		listNvidiaCloudFunctionVersionsResponse, err := d.client.ListNvidiaCloudFunctionVersions(ctx, functionID)
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(entryPath, "Failed to read Cloud Function versions", err.Error())
+			continue
+		}
+
+		versionNotFound := true
+		for _, f := range listNvidiaCloudFunctionVersionsResponse.Functions {
+			if f.VersionID == versionID {
+				functions[id] = NvidiaCloudFunctionsByIdFunctionModel{
+					FunctionID:     types.StringValue(f.ID),
+					VersionID:      types.StringValue(f.VersionID),
+					FunctionName:   types.StringValue(f.Name),
+					Status:         types.StringValue(f.Status),
+					FunctionType:   types.StringValue(f.FunctionType),
+					InferenceUrl:   types.StringValue(f.InferenceURL),
+					ContainerImage: types.StringValue(f.ContainerImage),
+					HelmChart:      types.StringValue(f.HelmChart),
+					Description:    types.StringValue(f.Description),
+				}
+				versionNotFound = false
+				break
+			}
+		}
+
+		if versionNotFound {
+			resp.Diagnostics.AddAttributeWarning(entryPath, "Version ID Not Found Error", fmt.Sprintf("Unable to find the target version ID %s", versionID))
+		}
+	}
+
+	functionsMap, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: (&NvidiaCloudFunctionsByIdFunctionModel{}).attrTypes()}, functions)
+	resp.Diagnostics.Append(diags...)
+	data.Functions = functionsMap
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
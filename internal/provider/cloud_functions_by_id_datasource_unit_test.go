@@ -0,0 +1,96 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestNvidiaCloudFunctionsByIdDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rt := &routingRoundTripper{routes: map[string]*http.Response{
+		"GET /v2/orgs/mock-org/nvcf/functions/mock-function-id/versions": mockJsonResponse(http.StatusOK,
+			`{"functions":[{"id":"mock-function-id","versionId":"mock-version-1","name":"mock-function","status":"ACTIVE"},`+
+				`{"id":"mock-function-id","versionId":"mock-version-2","name":"mock-function","status":"INACTIVE"}]}`),
+		"GET /v2/orgs/mock-org/nvcf/functions/mock-other-function-id/versions": mockJsonResponse(http.StatusOK,
+			`{"functions":[{"id":"mock-other-function-id","versionId":"mock-version-3","name":"mock-other-function","status":"DEPLOYING"}]}`),
+	}}
+	d := &NvidiaCloudFunctionsByIdDataSource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	ids := []string{"mock-function-id,mock-version-1", "mock-function-id,mock-version-2", "mock-other-function-id,mock-version-3", "mock-function-id,mock-missing-version"}
+	idValues := make([]tftypes.Value, 0, len(ids))
+	for _, id := range ids {
+		idValues = append(idValues, tftypes.NewValue(tftypes.String, id))
+	}
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"ids":       tftypes.NewValue(schemaType.AttributeTypes["ids"], idValues),
+				"functions": tftypes.NewValue(schemaType.AttributeTypes["functions"], nil),
+			}),
+		},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaType, nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+
+	// The missing version is reported without failing the other entries.
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, 1, resp.Diagnostics.WarningsCount(), resp.Diagnostics)
+
+	var data NvidiaCloudFunctionsByIdDataSourceModel
+	assert.False(t, resp.State.Get(ctx, &data).HasError())
+
+	functions := map[string]NvidiaCloudFunctionsByIdFunctionModel{}
+	assert.False(t, data.Functions.ElementsAs(ctx, &functions, false).HasError())
+	assert.Len(t, functions, 3)
+	assert.Equal(t, "ACTIVE", functions["mock-function-id,mock-version-1"].Status.ValueString())
+	assert.Equal(t, "INACTIVE", functions["mock-function-id,mock-version-2"].Status.ValueString())
+	assert.Equal(t, "mock-other-function", functions["mock-other-function-id,mock-version-3"].FunctionName.ValueString())
+
+	// The versions of each function are listed once.
+	assert.Equal(t, []string{
+		"GET /v2/orgs/mock-org/nvcf/functions/mock-function-id/versions",
+		"GET /v2/orgs/mock-org/nvcf/functions/mock-other-function-id/versions",
+	}, rt.requests)
+}
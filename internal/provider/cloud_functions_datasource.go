@@ -0,0 +1,178 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NvidiaCloudFunctionsDataSource{}
+
+func NewNvidiaCloudFunctionsDataSource() datasource.DataSource {
+	return &NvidiaCloudFunctionsDataSource{}
+}
+
+// NvidiaCloudFunctionsDataSource defines the data source implementation.
+type NvidiaCloudFunctionsDataSource struct {
+	client *utils.NVCFClient
+}
+
+// NvidiaCloudFunctionsDataSourceModel describes the data source data model.
+type NvidiaCloudFunctionsDataSourceModel struct {
+	Tags      types.List `tfsdk:"tags"`
+	Functions types.List `tfsdk:"functions"`
+}
+
+// NvidiaCloudFunctionsFunctionModel describes a single function version listed by the data source.
+type NvidiaCloudFunctionsFunctionModel struct {
+	FunctionID   types.String `tfsdk:"function_id"`
+	VersionID    types.String `tfsdk:"version_id"`
+	FunctionName types.String `tfsdk:"function_name"`
+	Status       types.String `tfsdk:"status"`
+	FunctionType types.String `tfsdk:"function_type"`
+	Tags         types.List   `tfsdk:"tags"`
+}
+
+func (m *NvidiaCloudFunctionsFunctionModel) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"function_id":   types.StringType,
+		"version_id":    types.StringType,
+		"function_name": types.StringType,
+		"status":        types.StringType,
+		"function_type": types.StringType,
+		"tags":          types.ListType{ElemType: types.StringType},
+	}
+}
+
+func (d *NvidiaCloudFunctionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_functions"
+}
+
+func (d *NvidiaCloudFunctionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the function versions of the organization, optionally filtered by tags.",
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only list the function versions carrying all of these tags",
+			},
+			"functions": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Function versions matching `tags`",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"function_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function ID",
+						},
+						"version_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function Version ID",
+						},
+						"function_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function name",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function Version status, e.g. \"ACTIVE\"",
+						},
+						"function_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Function type",
+						},
+						"tags": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Function Version tags",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NvidiaCloudFunctionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	ngcClient, ok := req.ProviderData.(*utils.NGCClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *NGCClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = ngcClient.NVCFClient()
+}
+
+func (d *NvidiaCloudFunctionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NvidiaCloudFunctionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags []string
+	if !data.Tags.IsNull() {
+		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	listNvidiaCloudFunctionsResponse, err := d.client.ListNvidiaCloudFunctions(ctx, tags)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list Cloud Functions", err.Error())
+		return
+	}
+
+	functions := make([]NvidiaCloudFunctionsFunctionModel, 0, len(listNvidiaCloudFunctionsResponse.Functions))
+	for _, f := range listNvidiaCloudFunctionsResponse.Functions {
+		functionTags, diags := types.ListValueFrom(ctx, types.StringType, f.Tags)
+		resp.Diagnostics.Append(diags...)
+
+		functions = append(functions, NvidiaCloudFunctionsFunctionModel{
+			FunctionID:   types.StringValue(f.ID),
+			VersionID:    types.StringValue(f.VersionID),
+			FunctionName: types.StringValue(f.Name),
+			Status:       types.StringValue(f.Status),
+			FunctionType: types.StringValue(f.FunctionType),
+			Tags:         functionTags,
+		})
+	}
+
+	functionsList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: (&NvidiaCloudFunctionsFunctionModel{}).attrTypes()}, functions)
+	resp.Diagnostics.Append(diags...)
+	data.Functions = functionsList
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,85 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+func TestNvidiaCloudFunctionsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rt := &routingRoundTripper{routes: map[string]*http.Response{
+		"GET /v2/orgs/mock-org/nvcf/functions": mockJsonResponse(http.StatusOK,
+			`{"functions":[{"id":"mock-function-id","versionId":"mock-version-1","name":"mock-function","status":"ACTIVE","tags":["team:ml","env:prod"]},`+
+				`{"id":"mock-function-id","versionId":"mock-version-2","name":"mock-function","status":"INACTIVE","tags":["team:ml"]},`+
+				`{"id":"mock-other-function-id","versionId":"mock-version-3","name":"mock-other-function","status":"ACTIVE"}]}`),
+	}}
+	d := &NvidiaCloudFunctionsDataSource{
+		client: &utils.NVCFClient{
+			NgcEndpoint: "https://api.ngc.nvidia.com",
+			NgcApiKey:   "mock-api-key",
+			NgcOrg:      "mock-org",
+			HttpClient:  &http.Client{Transport: rt},
+		},
+	}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"tags": tftypes.NewValue(schemaType.AttributeTypes["tags"], []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "team:ml"),
+					tftypes.NewValue(tftypes.String, "env:prod"),
+				}),
+				"functions": tftypes.NewValue(schemaType.AttributeTypes["functions"], nil),
+			}),
+		},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaType, nil),
+		},
+	}
+
+	d.Read(ctx, req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var data NvidiaCloudFunctionsDataSourceModel
+	assert.False(t, resp.State.Get(ctx, &data).HasError())
+
+	// Only the version carrying all the tags is listed.
+	var functions []NvidiaCloudFunctionsFunctionModel
+	assert.False(t, data.Functions.ElementsAs(ctx, &functions, false).HasError())
+	assert.Len(t, functions, 1)
+	assert.Equal(t, "mock-version-1", functions[0].VersionID.ValueString())
+	assert.Equal(t, "ACTIVE", functions[0].Status.ValueString())
+	assert.Equal(t, []string{"GET /v2/orgs/mock-org/nvcf/functions"}, rt.requests)
+}
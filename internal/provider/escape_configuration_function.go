@@ -0,0 +1,150 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &EscapeConfigurationFunction{}
+
+func NewEscapeConfigurationFunction() function.Function {
+	return &EscapeConfigurationFunction{}
+}
+
+// EscapeConfigurationFunction renders an HCL value as the JSON string expected by the deployment `configuration`.
+type EscapeConfigurationFunction struct{}
+
+func (f *EscapeConfigurationFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "escape_configuration"
+}
+
+func (f *EscapeConfigurationFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Renders a deployment configuration as JSON",
+		MarkdownDescription: "Renders an HCL object, e.g. the values overriding the `values.yaml` of a helm chart, as the JSON string expected by the `configuration` " +
+			"of the deployment specifications, so it doesn't have to be escaped by hand.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "configuration",
+				MarkdownDescription: "Deployment configuration, e.g. `{ image = { tag = \"1.0.0\" } }`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EscapeConfigurationFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var configuration types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &configuration))
+
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := configuration.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	configurationValue, err := configurationJSONValue(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	configurationJSON, err := json.Marshal(configurationValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, string(configurationJSON)))
+}
+
+// configurationJSONValue converts a Terraform value to its JSON counterpart, objects and maps become JSON objects
+// and lists, sets and tuples JSON arrays.
+func configurationJSONValue(value tftypes.Value) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("the configuration must be known, got an unknown %s", value.Type())
+	}
+
+	switch value.Type().(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+
+		array := make([]interface{}, 0, len(elements))
+		for _, element := range elements {
+			v, err := configurationJSONValue(element)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, v)
+		}
+		return array, nil
+	case tftypes.Map, tftypes.Object:
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return nil, err
+		}
+
+		object := make(map[string]interface{}, len(attributes))
+		for name, attribute := range attributes {
+			v, err := configurationJSONValue(attribute)
+			if err != nil {
+				return nil, err
+			}
+			object[name] = v
+		}
+		return object, nil
+	}
+
+	switch {
+	case value.Type().Equal(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case value.Type().Equal(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case value.Type().Equal(tftypes.Number):
+		n := new(big.Float)
+		err := value.As(&n)
+		if err != nil {
+			return nil, err
+		}
+		// Whole numbers are kept out of the exponent form, Helm templates print them as they are.
+		if n.IsInt() {
+			return json.Number(n.Text('f', -1)), nil
+		}
+		return json.Number(n.Text('g', -1)), nil
+	default:
+		return nil, fmt.Errorf("unsupported configuration value of type %s", value.Type())
+	}
+}
@@ -0,0 +1,101 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEscapeConfigurationFunction_Run(t *testing.T) {
+	t.Parallel()
+
+	configuration := types.ObjectValueMust(
+		map[string]attr.Type{
+			"image":    types.ObjectType{AttrTypes: map[string]attr.Type{"tag": types.StringType}},
+			"replicas": types.NumberType,
+			"debug":    types.BoolType,
+			"args":     types.TupleType{ElemTypes: []attr.Type{types.StringType, types.StringType}},
+			"extra":    types.StringType,
+		},
+		map[string]attr.Value{
+			"image":    types.ObjectValueMust(map[string]attr.Type{"tag": types.StringType}, map[string]attr.Value{"tag": types.StringValue("1.0.0 \"rc\"")}),
+			"replicas": types.NumberValue(big.NewFloat(2)),
+			"debug":    types.BoolValue(true),
+			"args":     types.TupleValueMust([]attr.Type{types.StringType, types.StringType}, []attr.Value{types.StringValue("--port"), types.StringValue("8000")}),
+			"extra":    types.StringNull(),
+		},
+	)
+
+	tests := []struct {
+		name     string
+		argument attr.Value
+		expected string
+	}{
+		{
+			name:     "object",
+			argument: configuration,
+			expected: `{"args":["--port","8000"],"debug":true,"extra":null,"image":{"tag":"1.0.0 \"rc\""},"replicas":2}`,
+		},
+		{
+			name:     "map",
+			argument: types.MapValueMust(types.StringType, map[string]attr.Value{"b": types.StringValue("2"), "a": types.StringValue("1")}),
+			expected: `{"a":"1","b":"2"}`,
+		},
+		{
+			name:     "number",
+			argument: types.NumberValue(big.NewFloat(0.5)),
+			expected: `0.5`,
+		},
+		{
+			name:     "large integer",
+			argument: types.NumberValue(big.NewFloat(123456789)),
+			expected: `123456789`,
+		},
+		{
+			name:     "million",
+			argument: types.NumberValue(big.NewFloat(1000000)),
+			expected: `1000000`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := NewEscapeConfigurationFunction()
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(tt.argument)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			f.Run(context.Background(), req, resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tt.expected)) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
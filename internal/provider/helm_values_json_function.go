@@ -0,0 +1,92 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &HelmValuesJsonFunction{}
+
+func NewHelmValuesJsonFunction() function.Function {
+	return &HelmValuesJsonFunction{}
+}
+
+// HelmValuesJsonFunction reads a Helm values file as the JSON string expected by the deployment `configuration`.
+type HelmValuesJsonFunction struct{}
+
+func (f *HelmValuesJsonFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "helm_values_json"
+}
+
+func (f *HelmValuesJsonFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Reads a Helm values file as a deployment configuration",
+		MarkdownDescription: "Reads a YAML or JSON file overriding the `values.yaml` of a helm chart and returns it as the JSON string expected by the `configuration` " +
+			"of the deployment specifications, so the overrides can be kept in their own file.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path of the values file, relative paths are resolved from the working directory, e.g. `\"${path.module}/values.yaml\"`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *HelmValuesJsonFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &path))
+
+	if resp.Error != nil {
+		return
+	}
+
+	values, err := os.ReadFile(path)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	valuesJSON, err := helmValuesJSON(values)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid values file %s: %s", path, err.Error()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, valuesJSON))
+}
+
+// helmValuesJSON converts the YAML, or JSON, values to JSON. Like Helm, the values must be a mapping.
+func helmValuesJSON(values []byte) (string, error) {
+	var valuesMap map[string]interface{}
+	if err := yaml.Unmarshal(values, &valuesMap); err != nil {
+		return "", err
+	}
+
+	if valuesMap == nil {
+		return "", fmt.Errorf("no values found")
+	}
+
+	valuesJSON, err := json.Marshal(valuesMap)
+	if err != nil {
+		return "", err
+	}
+	return string(valuesJSON), nil
+}
@@ -0,0 +1,100 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelmValuesJsonFunction_Run(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	jsonValuesPath := filepath.Join(dir, "values.json")
+	assert.NoError(t, os.WriteFile(jsonValuesPath, []byte(`{"image": {"tag": "2.0.0"}}`), 0o600))
+	listValuesPath := filepath.Join(dir, "list.yaml")
+	assert.NoError(t, os.WriteFile(listValuesPath, []byte("- a\n- b\n"), 0o600))
+	emptyValuesPath := filepath.Join(dir, "empty.yaml")
+	assert.NoError(t, os.WriteFile(emptyValuesPath, []byte("# nothing\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "yaml",
+			path:     filepath.Join("testdata", "helm_values.yaml"),
+			expected: `{"args":["--port","8000"],"debug":false,"image":{"repository":"nvcr.io/mock-org/mock-image","tag":"1.0.0"},"replicaCount":2,"resources":{"limits":{"nvidia.com/gpu":1}}}`,
+		},
+		{
+			name:     "json",
+			path:     jsonValuesPath,
+			expected: `{"image":{"tag":"2.0.0"}}`,
+		},
+		{
+			name:    "missing",
+			path:    filepath.Join(dir, "missing.yaml"),
+			wantErr: true,
+		},
+		{
+			name:    "list",
+			path:    listValuesPath,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			path:    emptyValuesPath,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := NewHelmValuesJsonFunction()
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.path)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			f.Run(context.Background(), req, resp)
+
+			if tt.wantErr {
+				assert.NotNil(t, resp.Error)
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tt.expected)) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
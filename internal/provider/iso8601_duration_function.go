@@ -0,0 +1,114 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &Iso8601DurationFunction{}
+
+func NewIso8601DurationFunction() function.Function {
+	return &Iso8601DurationFunction{}
+}
+
+// maxIso8601DurationSeconds is far above any timeout, and low enough for the duration in milliseconds
+// to be exact in both float64 and int64.
+const maxIso8601DurationSeconds = 1e12
+
+// Iso8601DurationFunction renders a number of seconds as the ISO 8601 duration expected by the health `timeout`.
+type Iso8601DurationFunction struct{}
+
+func (f *Iso8601DurationFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "iso8601_duration"
+}
+
+func (f *Iso8601DurationFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Renders seconds as an ISO 8601 duration",
+		MarkdownDescription: "Renders a number of seconds as an ISO 8601 duration string in PnDTnHnMn.nS format, e.g. `90` as `PT1M30S`, " +
+			"for the `timeout` of the function health.",
+		Parameters: []function.Parameter{
+			function.Float64Parameter{
+				Name:                "seconds",
+				MarkdownDescription: "Duration in seconds, fractions are kept in the seconds component, rounded to the millisecond",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *Iso8601DurationFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seconds float64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &seconds))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if seconds < 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("seconds must be a non-negative number, got %v", seconds))
+		return
+	}
+
+	if seconds > maxIso8601DurationSeconds {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("seconds must be at most %g, got %v", maxIso8601DurationSeconds, seconds))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, iso8601Duration(seconds)))
+}
+
+// iso8601Duration omits the zero components, e.g. 3600 is rendered as PT1H and 0 as PT0S. The duration is
+// rounded to the millisecond and the fraction formatted in decimal, so binary float error doesn't leak into it.
+func iso8601Duration(seconds float64) string {
+	millis := int64(math.Round(seconds * 1000))
+	whole := millis / 1000
+
+	days := whole / 86400
+	hours := whole % 86400 / 3600
+	minutes := whole % 3600 / 60
+	secs := whole % 60
+	fraction := millis % 1000
+
+	var b strings.Builder
+	b.WriteString("P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+
+	if hours == 0 && minutes == 0 && secs == 0 && fraction == 0 && days > 0 {
+		return b.String()
+	}
+
+	b.WriteString("T")
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if secs > 0 || fraction > 0 || (days == 0 && hours == 0 && minutes == 0) {
+		if fraction > 0 {
+			fmt.Fprintf(&b, "%sS", strings.TrimRight(fmt.Sprintf("%d.%03d", secs, fraction), "0"))
+		} else {
+			fmt.Fprintf(&b, "%dS", secs)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIso8601DurationFunction_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		seconds     float64
+		expected    string
+		expectError bool
+	}{
+		{name: "zero", seconds: 0, expected: "PT0S"},
+		{name: "seconds", seconds: 10, expected: "PT10S"},
+		{name: "minutes and seconds", seconds: 90, expected: "PT1M30S"},
+		{name: "hours", seconds: 3600, expected: "PT1H"},
+		{name: "days", seconds: 172800, expected: "P2D"},
+		{name: "all components", seconds: 93784.5, expected: "P1DT2H3M4.5S"},
+		{name: "fraction", seconds: 0.25, expected: "PT0.25S"},
+		{name: "decimal fraction", seconds: 61.1, expected: "PT1M1.1S"},
+		{name: "fraction below the millisecond", seconds: 59.9996, expected: "PT1M"},
+		{name: "fraction of a day", seconds: 86400.5, expected: "P1DT0.5S"},
+		{name: "negative", seconds: -1, expectError: true},
+		{name: "maximum", seconds: 1e12, expected: "P11574074DT1H46M40S"},
+		{name: "above the maximum", seconds: 1e16, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := NewIso8601DurationFunction()
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.Float64Value(tt.seconds)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			f.Run(context.Background(), req, resp)
+
+			if tt.expectError {
+				if resp.Error == nil {
+					t.Fatalf("expected an error, got %s", resp.Result.Value())
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tt.expected)) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -3,14 +3,18 @@ package custom_planmodifier
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type CloudFunctionArtifactUriPlanModifier struct{}
+const DEFAULT_ARTIFACT_HOST = "https://api.ngc.nvidia.com"
+
+type CloudFunctionArtifactUriPlanModifier struct {
+	// DefaultHost is prepended to URIs without a host, DEFAULT_ARTIFACT_HOST when empty.
+	DefaultHost string
+}
 
 func (m CloudFunctionArtifactUriPlanModifier) Description(ctx context.Context) string {
 	return "Automatically adds artifact host name to URI if missing"
@@ -34,10 +38,10 @@ func (m CloudFunctionArtifactUriPlanModifier) PlanModifyString(ctx context.Conte
 		return
 	}
 
-	defaultHost := os.Getenv("NGC_ENDPOINT")
+	defaultHost := strings.TrimSuffix(m.DefaultHost, "/")
 
 	if defaultHost == "" {
-		defaultHost = "https://api.ngc.nvidia.com"
+		defaultHost = DEFAULT_ARTIFACT_HOST
 	}
 	resp.PlanValue = types.StringValue(fmt.Sprintf("%s/%s", defaultHost, strings.TrimPrefix(value, "/")))
 }
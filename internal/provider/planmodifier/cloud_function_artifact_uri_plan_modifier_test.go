@@ -15,7 +15,6 @@ package custom_planmodifier
 
 import (
 	"context"
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -50,7 +49,7 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 		name           string
 		planValue      string
 		stateValue     string
-		envEndpoint    string
+		configuredHost string
 		expectedResult string
 		description    string
 	}{
@@ -58,7 +57,7 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 			name:           "EmptyPlanAndStateValue",
 			planValue:      "",
 			stateValue:     "",
-			envEndpoint:    "",
+			configuredHost: "",
 			expectedResult: "",
 			description:    "Should return empty when both plan and state values are empty",
 		},
@@ -66,7 +65,7 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 			name:           "HttpsPrefix",
 			planValue:      "https://example.com/artifact",
 			stateValue:     "",
-			envEndpoint:    "",
+			configuredHost: "",
 			expectedResult: "https://example.com/artifact",
 			description:    "Should not modify URI with https:// prefix",
 		},
@@ -74,7 +73,7 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 			name:           "HttpPrefix",
 			planValue:      "http://example.com/artifact",
 			stateValue:     "",
-			envEndpoint:    "",
+			configuredHost: "",
 			expectedResult: "http://example.com/artifact",
 			description:    "Should not modify URI with http:// prefix",
 		},
@@ -82,7 +81,7 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 			name:           "RelativeUriWithDefaultHost",
 			planValue:      "v2/org/team/artifacts/test",
 			stateValue:     "",
-			envEndpoint:    "",
+			configuredHost: "",
 			expectedResult: "https://api.ngc.nvidia.com/v2/org/team/artifacts/test",
 			description:    "Should prepend default NGC endpoint for relative URIs",
 		},
@@ -90,15 +89,15 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 			name:           "RelativeUriWithCustomEnvEndpoint",
 			planValue:      "v2/org/team/artifacts/test",
 			stateValue:     "",
-			envEndpoint:    "https://custom.nvidia.com",
+			configuredHost: "https://custom.nvidia.com",
 			expectedResult: "https://custom.nvidia.com/v2/org/team/artifacts/test",
-			description:    "Should prepend custom NGC endpoint from env variable",
+			description:    "Should prepend custom NGC endpoint from the provider configuration",
 		},
 		{
 			name:           "RelativeUriWithLeadingSlash",
 			planValue:      "/v2/org/team/artifacts/test",
 			stateValue:     "",
-			envEndpoint:    "",
+			configuredHost: "",
 			expectedResult: "https://api.ngc.nvidia.com/v2/org/team/artifacts/test",
 			description:    "Should trim leading slash and prepend default host",
 		},
@@ -106,15 +105,23 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 			name:           "RelativeUriWithLeadingSlashAndCustomEndpoint",
 			planValue:      "/v2/org/team/artifacts/test",
 			stateValue:     "",
-			envEndpoint:    "https://custom.nvidia.com",
+			configuredHost: "https://custom.nvidia.com",
 			expectedResult: "https://custom.nvidia.com/v2/org/team/artifacts/test",
 			description:    "Should trim leading slash and prepend custom host",
 		},
+		{
+			name:           "CustomEndpointWithTrailingSlash",
+			planValue:      "v2/org/team/artifacts/test",
+			stateValue:     "",
+			configuredHost: "https://custom.nvidia.com/",
+			expectedResult: "https://custom.nvidia.com/v2/org/team/artifacts/test",
+			description:    "Should not double the slash after a configured host ending with one",
+		},
 		{
 			name:           "EmptyPlanValueWithStateValue",
 			planValue:      "",
 			stateValue:     "v2/org/team/artifacts/existing",
-			envEndpoint:    "",
+			configuredHost: "",
 			expectedResult: "https://api.ngc.nvidia.com/v2/org/team/artifacts/existing",
 			description:    "Should use state value when plan value is empty",
 		},
@@ -122,7 +129,7 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 			name:           "EmptyPlanValueWithStateValueHttps",
 			planValue:      "",
 			stateValue:     "https://example.com/existing",
-			envEndpoint:    "",
+			configuredHost: "",
 			expectedResult: "",
 			description:    "Should not modify plan value when state value already has https scheme (no prefix needed)",
 		},
@@ -130,7 +137,7 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 			name:           "PlanValueTakesPrecedence",
 			planValue:      "v2/org/new/artifacts",
 			stateValue:     "v2/org/old/artifacts",
-			envEndpoint:    "",
+			configuredHost: "",
 			expectedResult: "https://api.ngc.nvidia.com/v2/org/new/artifacts",
 			description:    "Plan value should take precedence over state value",
 		},
@@ -138,15 +145,7 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Set or unset environment variable
-			if tt.envEndpoint != "" {
-				os.Setenv("NGC_ENDPOINT", tt.envEndpoint)
-				defer os.Unsetenv("NGC_ENDPOINT")
-			} else {
-				os.Unsetenv("NGC_ENDPOINT")
-			}
-
-			modifier := CloudFunctionArtifactUriPlanModifier{}
+			modifier := CloudFunctionArtifactUriPlanModifier{DefaultHost: tt.configuredHost}
 			ctx := context.Background()
 
 			req := planmodifier.StringRequest{
@@ -175,8 +174,6 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString(t *testing.T) {
 func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString_NullValues(t *testing.T) {
 	t.Parallel()
 
-	os.Unsetenv("NGC_ENDPOINT")
-
 	modifier := CloudFunctionArtifactUriPlanModifier{}
 	ctx := context.Background()
 
@@ -198,8 +195,6 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString_NullValues(t *tes
 func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString_UnknownValues(t *testing.T) {
 	t.Parallel()
 
-	os.Unsetenv("NGC_ENDPOINT")
-
 	modifier := CloudFunctionArtifactUriPlanModifier{}
 	ctx := context.Background()
 
@@ -217,3 +212,21 @@ func TestCloudFunctionArtifactUriPlanModifier_PlanModifyString_UnknownValues(t *
 	// Unknown values return empty string from ValueString(), so state value should be used
 	assert.Equal(t, "https://api.ngc.nvidia.com/v2/org/team/artifacts/test", resp.PlanValue.ValueString())
 }
+
+func TestCloudFunctionArtifactUriPlanModifier_IgnoresEnvironment(t *testing.T) {
+	// The endpoint comes from the provider configuration, NGC_ENDPOINT is only its fallback.
+	t.Setenv("NGC_ENDPOINT", "https://env.nvidia.com")
+
+	modifier := CloudFunctionArtifactUriPlanModifier{}
+	req := planmodifier.StringRequest{
+		PlanValue:  types.StringValue("v2/org/team/artifacts/test"),
+		StateValue: types.StringNull(),
+	}
+	resp := &planmodifier.StringResponse{
+		PlanValue: req.PlanValue,
+	}
+
+	modifier.PlanModifyString(context.Background(), req, resp)
+
+	assert.Equal(t, DEFAULT_ARTIFACT_HOST+"/v2/org/team/artifacts/test", resp.PlanValue.ValueString())
+}
@@ -0,0 +1,60 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package custom_planmodifier
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultHealthUris maps an API body format to the health endpoint its inference server exposes.
+var DefaultHealthUris = map[string]string{
+	// Triton (KServe v2 predict protocol) readiness endpoint.
+	"PREDICT_V2": "/v2/health/ready",
+}
+
+type CloudFunctionHealthUriPlanModifier struct{}
+
+func (m CloudFunctionHealthUriPlanModifier) Description(ctx context.Context) string {
+	return "Defaults the health endpoint based on the API body format when no health check is configured"
+}
+
+func (m CloudFunctionHealthUriPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return "Defaults the health endpoint based on the API body format when no health check is configured"
+}
+
+func (m CloudFunctionHealthUriPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() || !resp.PlanValue.IsUnknown() {
+		return
+	}
+
+	var health types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("health"), &health)...)
+
+	// The health block takes precedence, NVCF derives health_uri from it.
+	if resp.Diagnostics.HasError() || !health.IsNull() {
+		return
+	}
+
+	var apiBodyFormat types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("api_body_format"), &apiBodyFormat)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if healthUri, ok := DefaultHealthUris[apiBodyFormat.ValueString()]; ok {
+		resp.PlanValue = types.StringValue(healthUri)
+	}
+}
@@ -0,0 +1,135 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package custom_planmodifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+var testHealthUriSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"api_body_format": schema.StringAttribute{Optional: true, Computed: true},
+		"health_uri":      schema.StringAttribute{Optional: true, Computed: true},
+		"health": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"uri": schema.StringAttribute{Required: true},
+			},
+		},
+	},
+}
+
+func testHealthUriRaw(apiBodyFormat string, healthUri tftypes.Value, healthUriInHealth *string) tftypes.Value {
+	healthType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"uri": tftypes.String}}
+	health := tftypes.NewValue(healthType, nil)
+	if healthUriInHealth != nil {
+		health = tftypes.NewValue(healthType, map[string]tftypes.Value{"uri": tftypes.NewValue(tftypes.String, *healthUriInHealth)})
+	}
+
+	return tftypes.NewValue(
+		tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"api_body_format": tftypes.String,
+			"health_uri":      tftypes.String,
+			"health":          healthType,
+		}},
+		map[string]tftypes.Value{
+			"api_body_format": tftypes.NewValue(tftypes.String, apiBodyFormat),
+			"health_uri":      healthUri,
+			"health":          health,
+		},
+	)
+}
+
+func TestCloudFunctionHealthUriPlanModifier_PlanModifyString(t *testing.T) {
+	t.Parallel()
+
+	healthBlockUri := "/v2/health/live"
+
+	tests := []struct {
+		name           string
+		apiBodyFormat  string
+		configValue    types.String
+		planValue      types.String
+		healthBlockUri *string
+		expectedResult types.String
+	}{
+		{
+			name:           "PredictV2Default",
+			apiBodyFormat:  "PREDICT_V2",
+			configValue:    types.StringNull(),
+			planValue:      types.StringUnknown(),
+			expectedResult: types.StringValue("/v2/health/ready"),
+		},
+		{
+			name:           "CustomHasNoDefault",
+			apiBodyFormat:  "CUSTOM",
+			configValue:    types.StringNull(),
+			planValue:      types.StringUnknown(),
+			expectedResult: types.StringUnknown(),
+		},
+		{
+			name:           "ConfiguredValueKept",
+			apiBodyFormat:  "PREDICT_V2",
+			configValue:    types.StringValue("/v2/health/live"),
+			planValue:      types.StringValue("/v2/health/live"),
+			expectedResult: types.StringValue("/v2/health/live"),
+		},
+		{
+			name:           "StateValueKept",
+			apiBodyFormat:  "PREDICT_V2",
+			configValue:    types.StringNull(),
+			planValue:      types.StringValue("/v2/health/live"),
+			expectedResult: types.StringValue("/v2/health/live"),
+		},
+		{
+			name:           "HealthBlockTakesPrecedence",
+			apiBodyFormat:  "PREDICT_V2",
+			configValue:    types.StringNull(),
+			planValue:      types.StringUnknown(),
+			healthBlockUri: &healthBlockUri,
+			expectedResult: types.StringUnknown(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			configHealthUri, _ := tt.configValue.ToTerraformValue(ctx)
+			planHealthUri, _ := tt.planValue.ToTerraformValue(ctx)
+
+			req := planmodifier.StringRequest{
+				ConfigValue: tt.configValue,
+				PlanValue:   tt.planValue,
+				Config:      tfsdk.Config{Schema: testHealthUriSchema, Raw: testHealthUriRaw(tt.apiBodyFormat, configHealthUri, tt.healthBlockUri)},
+				Plan:        tfsdk.Plan{Schema: testHealthUriSchema, Raw: testHealthUriRaw(tt.apiBodyFormat, planHealthUri, tt.healthBlockUri)},
+			}
+			resp := &planmodifier.StringResponse{
+				PlanValue: req.PlanValue,
+			}
+
+			CloudFunctionHealthUriPlanModifier{}.PlanModifyString(ctx, req, resp)
+
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, tt.expectedResult, resp.PlanValue)
+		})
+	}
+}
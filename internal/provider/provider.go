@@ -12,11 +12,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -38,10 +42,17 @@ type NgcProvider struct {
 
 // NgcProviderModel describes the provider data model.
 type NgcProviderModel struct {
-	NgcEndpoint types.String `tfsdk:"ngc_endpoint"`
-	NgcApiKey   types.String `tfsdk:"ngc_api_key"`
-	NgcOrg      types.String `tfsdk:"ngc_org"`
-	NgcTeam     types.String `tfsdk:"ngc_team"`
+	NgcEndpoint              types.String `tfsdk:"ngc_endpoint"`
+	InvokeEndpoint           types.String `tfsdk:"invoke_endpoint"`
+	NgcApiKey                types.String `tfsdk:"ngc_api_key"`
+	NgcOrg                   types.String `tfsdk:"ngc_org"`
+	NgcTeam                  types.String `tfsdk:"ngc_team"`
+	ApiVersion               types.String `tfsdk:"api_version"`
+	RetryBudget              types.String `tfsdk:"retry_budget"`
+	RequestTimeout           types.String `tfsdk:"request_timeout"`
+	TagProviderVersion       types.Bool   `tfsdk:"tag_provider_version"`
+	ExtraHeaders             types.Map    `tfsdk:"extra_headers"`
+	DeploymentDriftDetection types.Bool   `tfsdk:"deployment_drift_detection"`
 }
 
 func (p *NgcProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -56,6 +67,10 @@ func (p *NgcProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				MarkdownDescription: "NGC API endpoint",
 				Optional:            true,
 			},
+			"invoke_endpoint": schema.StringAttribute{
+				MarkdownDescription: "NVCF invocation endpoint used to build invocation URLs, e.g. \"https://api.nvcf.nvidia.com\". Derived from `ngc_endpoint` when unset, \"https://api.nvcf.nvidia.com\" for an unknown endpoint",
+				Optional:            true,
+			},
 			"ngc_api_key": schema.StringAttribute{
 				MarkdownDescription: "NGC Personal Token with `Cloud Function` permission",
 				Optional:            true,
@@ -69,12 +84,39 @@ func (p *NgcProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				MarkdownDescription: "NGC Team Name",
 				Optional:            true,
 			},
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: "Version prefix of the NVCF API paths, e.g. \"v3\", or the prefix expected by an API gateway. Default is \"v2\"",
+				Optional:            true,
+			},
+			"retry_budget": schema.StringAttribute{
+				MarkdownDescription: "Total time spent waiting between retries of transient NVCF API failures, shared by all resources in a single run. Go duration format, e.g. \"5m\". Default is \"0s\", which disables retries.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of a single NVCF API call. A call exceeding it is retried within `retry_budget`, while the resource timeouts still bound the whole operation. Go duration format, e.g. \"30s\". Default is \"0s\", which disables it.",
+				Optional:            true,
+			},
+			"tag_provider_version": schema.BoolAttribute{
+				MarkdownDescription: "Tag cloud functions with the provider version which created or last updated them, e.g. \"managed_by_provider_version:1.2.0\". Default is \"false\"",
+				Optional:            true,
+			},
+			"deployment_drift_detection": schema.BoolAttribute{
+				MarkdownDescription: "Report the deployment of a cloud function version deleted outside of Terraform as drift, so the next apply deploys the version again. " +
+					"Otherwise the deployment specifications are kept from the state. Default is \"false\"",
+				Optional: true,
+			},
+			"extra_headers": schema.MapAttribute{
+				MarkdownDescription: "HTTP headers added to every NVCF API request, e.g. a routing tag required by a gateway in front of NVCF. The `Authorization` and `Content-Type` headers are reserved",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
 		},
 	}
 }
 
 func (p *NgcProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	ngcEndpoint := os.Getenv("NGC_ENDPOINT")
+	invokeEndpoint := os.Getenv("NGC_INVOKE_ENDPOINT")
 	ngcApiKey := os.Getenv("NGC_API_KEY")
 	ngcOrg := os.Getenv("NGC_ORG")
 	ngcTeam := os.Getenv("NGC_TEAM")
@@ -123,6 +165,51 @@ func (p *NgcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		ngcEndpoint = "https://api.ngc.nvidia.com"
 	}
 
+	if data.InvokeEndpoint.ValueString() != "" {
+		invokeEndpoint = data.InvokeEndpoint.ValueString()
+	}
+
+	var retryBudget time.Duration
+	if data.RetryBudget.ValueString() != "" {
+		var err error
+		retryBudget, err = time.ParseDuration(data.RetryBudget.ValueString())
+
+		if err != nil || retryBudget < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_budget"),
+				"Invalid retry_budget Configuration",
+				fmt.Sprintf("Expected a non-negative duration such as \"5m\". Got: %q", data.RetryBudget.ValueString()),
+			)
+		}
+	}
+
+	var requestTimeout time.Duration
+	if data.RequestTimeout.ValueString() != "" {
+		var err error
+		requestTimeout, err = time.ParseDuration(data.RequestTimeout.ValueString())
+
+		if err != nil || requestTimeout < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid request_timeout Configuration",
+				fmt.Sprintf("Expected a non-negative duration such as \"30s\". Got: %q", data.RequestTimeout.ValueString()),
+			)
+		}
+	}
+
+	extraHeaders := map[string]string{}
+	resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &extraHeaders, false)...)
+
+	for key := range extraHeaders {
+		if utils.IsReservedHeader(key) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra_headers"),
+				"Invalid extra_headers Configuration",
+				fmt.Sprintf("The %s header is set by the provider and can't be overridden.", key),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -130,12 +217,23 @@ func (p *NgcProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	httpClient := cleanhttp.DefaultPooledClient()
 
 	client := &utils.NGCClient{
-		NgcEndpoint: ngcEndpoint,
-		NgcApiKey:   ngcApiKey,
-		NgcOrg:      ngcOrg,
-		NgcTeam:     ngcTeam,
-		HttpClient:  httpClient,
+		NgcEndpoint:    ngcEndpoint,
+		NgcApiKey:      ngcApiKey,
+		NgcOrg:         ngcOrg,
+		NgcTeam:        ngcTeam,
+		ApiVersion:     strings.Trim(data.ApiVersion.ValueString(), "/"),
+		InvokeEndpoint: invokeEndpoint,
+		HttpClient:     httpClient,
+		RetryBudget:    utils.NewRetryBudget(retryBudget),
+		RequestTimeout: requestTimeout,
+		ExtraHeaders:   extraHeaders,
 	}
+
+	if data.TagProviderVersion.ValueBool() {
+		client.ProviderVersion = p.version
+	}
+	client.DeploymentDriftDetection = data.DeploymentDriftDetection.ValueBool()
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
@@ -144,6 +242,7 @@ func (p *NgcProvider) Resources(ctx context.Context) []func() resource.Resource
 	return []func() resource.Resource{
 		NewNvidiaCloudFunctionResource,
 		NewNvidiaCloudFunctionTelemetryResource,
+		NewNvidiaCloudFunctionDeploymentResource,
 	}
 }
 
@@ -151,11 +250,22 @@ func (p *NgcProvider) DataSources(ctx context.Context) []func() datasource.DataS
 	return []func() datasource.DataSource{
 		NewNvidiaCloudFunctionDataSource,
 		NewNvidiaCloudFunctionTelemetryDataSource,
+		NewNvidiaCloudFunctionInvokeHostDataSource,
+		NewNvidiaCloudFunctionActiveVersionDataSource,
+		NewNvidiaCloudFunctionsByIdDataSource,
+		NewNvidiaCloudFunctionsDataSource,
+		NewNvidiaCloudFunctionCapacityDataSource,
+		NewNvidiaCloudFunctionVersionHistoryDataSource,
 	}
 }
 
 func (p *NgcProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewEscapeConfigurationFunction,
+		NewArtifactUriFunction,
+		NewIso8601DurationFunction,
+		NewHelmValuesJsonFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -0,0 +1,150 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"gitlab-master.nvidia.com/nvb/core/terraform-provider-ngc/internal/provider/utils"
+)
+
+// testProviderConfig builds a provider configuration, leaving the attributes missing in values unset.
+func testProviderConfig(t *testing.T, p provider.Provider, values map[string]tftypes.Value) tfsdk.Config {
+	ctx := context.Background()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	assert.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+
+	raw := map[string]tftypes.Value{}
+	for name, attribute := range schemaResp.Schema.Attributes {
+		if v, ok := values[name]; ok {
+			raw[name] = v
+		} else {
+			raw[name] = tftypes.NewValue(attribute.GetType().TerraformType(ctx), nil)
+		}
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), raw),
+	}
+}
+
+func TestProviderConfigure_WithoutEnvironment(t *testing.T) {
+	for _, env := range []string{"NGC_ENDPOINT", "NGC_INVOKE_ENDPOINT", "NGC_API_KEY", "NGC_ORG", "NGC_TEAM"} {
+		t.Setenv(env, "")
+	}
+
+	p := New("test")()
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, p, map[string]tftypes.Value{
+			"ngc_endpoint":    tftypes.NewValue(tftypes.String, "https://api.stg.ngc.nvidia.com"),
+			"invoke_endpoint": tftypes.NewValue(tftypes.String, "https://mock-invoke.nvidia.com"),
+			"ngc_api_key":     tftypes.NewValue(tftypes.String, "mock-api-key"),
+			"ngc_org":         tftypes.NewValue(tftypes.String, "mock-org"),
+			"ngc_team":        tftypes.NewValue(tftypes.String, "mock-team"),
+
+			"deployment_drift_detection": tftypes.NewValue(tftypes.Bool, true),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(context.Background(), req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	client, ok := resp.ResourceData.(*utils.NGCClient)
+	assert.True(t, ok)
+	assert.Equal(t, "https://api.stg.ngc.nvidia.com", client.NgcEndpoint)
+	assert.Equal(t, "mock-api-key", client.NgcApiKey)
+	assert.Equal(t, "mock-org", client.NgcOrg)
+	assert.Equal(t, "mock-team", client.NgcTeam)
+	assert.Equal(t, "https://mock-invoke.nvidia.com", client.InvokeEndpoint)
+	assert.True(t, client.DeploymentDriftDetection)
+}
+
+func TestProviderConfigure_EnvironmentFallback(t *testing.T) {
+	t.Setenv("NGC_ENDPOINT", "https://api.stg.ngc.nvidia.com")
+	t.Setenv("NGC_INVOKE_ENDPOINT", "https://mock-env-invoke.nvidia.com")
+	t.Setenv("NGC_API_KEY", "mock-env-api-key")
+	t.Setenv("NGC_ORG", "mock-env-org")
+	t.Setenv("NGC_TEAM", "")
+
+	p := New("test")()
+	req := provider.ConfigureRequest{
+		Config: testProviderConfig(t, p, map[string]tftypes.Value{
+			"ngc_org": tftypes.NewValue(tftypes.String, "mock-org"),
+		}),
+	}
+	resp := &provider.ConfigureResponse{}
+
+	p.Configure(context.Background(), req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	client, ok := resp.ResourceData.(*utils.NGCClient)
+	assert.True(t, ok)
+	assert.Equal(t, "https://api.stg.ngc.nvidia.com", client.NgcEndpoint)
+	assert.Equal(t, "https://mock-env-invoke.nvidia.com", client.InvokeEndpoint)
+	assert.Equal(t, "mock-env-api-key", client.NgcApiKey)
+	// The configuration takes precedence over the environment.
+	assert.Equal(t, "mock-org", client.NgcOrg)
+	assert.False(t, client.DeploymentDriftDetection)
+}
+
+func TestProviderConfigure_ExtraHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]tftypes.Value
+		wantErr bool
+	}{
+		{
+			name:    "CustomHeader",
+			headers: map[string]tftypes.Value{"X-Routing-Tag": tftypes.NewValue(tftypes.String, "mock-routing-tag")},
+		},
+		{
+			name:    "ReservedHeader",
+			headers: map[string]tftypes.Value{"authorization": tftypes.NewValue(tftypes.String, "Bearer mock-override")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New("test")()
+			req := provider.ConfigureRequest{
+				Config: testProviderConfig(t, p, map[string]tftypes.Value{
+					"ngc_api_key":   tftypes.NewValue(tftypes.String, "mock-api-key"),
+					"ngc_org":       tftypes.NewValue(tftypes.String, "mock-org"),
+					"extra_headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tt.headers),
+				}),
+			}
+			resp := &provider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+			if !tt.wantErr {
+				client, ok := resp.ResourceData.(*utils.NGCClient)
+				assert.True(t, ok)
+				assert.Equal(t, map[string]string{"X-Routing-Tag": "mock-routing-tag"}, client.ExtraHeaders)
+			}
+		})
+	}
+}
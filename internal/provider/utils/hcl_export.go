@@ -0,0 +1,175 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// FunctionConfigHCL renders an `import` block and the matching `ngc_cloud_function` resource block
+// for an existing function version, so it can be adopted by Terraform.
+// Tags starting with one of the excluded prefixes are left out, e.g. the tags the provider manages itself.
+// Secret values cannot be read back from NVCF, so secrets are not rendered.
+func FunctionConfigHCL(resourceName string, function NvidiaCloudFunctionInfo, deployment *NvidiaCloudFunctionDeployment, excludedTagPrefixes ...string) string {
+	file := hclwrite.NewEmptyFile()
+	root := file.Body()
+
+	importBody := root.AppendNewBlock("import", nil).Body()
+	importBody.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: "ngc_cloud_function"},
+		hcl.TraverseAttr{Name: resourceName},
+	})
+	importBody.SetAttributeValue("id", cty.StringVal(function.ID+","+function.VersionID))
+	root.AppendNewline()
+
+	body := root.AppendNewBlock("resource", []string{"ngc_cloud_function", resourceName}).Body()
+	setStringAttribute(body, "function_name", function.Name)
+	setStringAttribute(body, "description", function.Description)
+	setStringAttribute(body, "function_type", function.FunctionType)
+	setStringAttribute(body, "helm_chart", function.HelmChart)
+	setStringAttribute(body, "helm_chart_service_name", function.HelmChartServiceName)
+	setStringAttribute(body, "container_image", function.ContainerImage)
+	setStringAttribute(body, "container_args", function.ContainerArgs)
+	setStringAttribute(body, "inference_url", function.InferenceURL)
+	if function.InferencePort != 0 {
+		body.SetAttributeValue("inference_port", cty.NumberIntVal(int64(function.InferencePort)))
+	}
+	setStringAttribute(body, "api_body_format", function.APIBodyFormat)
+
+	// NVCF derives health_uri from the health block, only one of them is rendered.
+	if function.Health != nil {
+		body.SetAttributeValue("health", cty.ObjectVal(map[string]cty.Value{
+			"protocol":             cty.StringVal(function.Health.Protocol),
+			"uri":                  cty.StringVal(function.Health.URI),
+			"port":                 cty.NumberIntVal(int64(function.Health.Port)),
+			"timeout":              cty.StringVal(function.Health.Timeout),
+			"expected_status_code": cty.NumberIntVal(int64(function.Health.ExpectedStatusCode)),
+		}))
+	} else {
+		setStringAttribute(body, "health_uri", function.HealthURI)
+	}
+
+	if len(function.ContainerEnvironment) > 0 {
+		environments := make([]cty.Value, 0, len(function.ContainerEnvironment))
+		for _, v := range function.ContainerEnvironment {
+			environments = append(environments, cty.ObjectVal(map[string]cty.Value{
+				"key":   cty.StringVal(v.Key),
+				"value": cty.StringVal(v.Value),
+			}))
+		}
+		body.SetAttributeValue("container_environment", cty.ListVal(environments))
+	}
+
+	if len(function.Models) > 0 {
+		models := make([]cty.Value, 0, len(function.Models))
+		for _, v := range function.Models {
+			models = append(models, artifactValue(v.Name, v.Version, v.URI))
+		}
+		body.SetAttributeValue("models", cty.ListVal(models))
+	}
+
+	if len(function.Resources) > 0 {
+		resources := make([]cty.Value, 0, len(function.Resources))
+		for _, v := range function.Resources {
+			resources = append(resources, artifactValue(v.Name, v.Version, v.URI))
+		}
+		body.SetAttributeValue("resources", cty.ListVal(resources))
+	}
+
+	tags := make([]cty.Value, 0, len(function.Tags))
+	for _, tag := range function.Tags {
+		if !hasAnyPrefix(tag, excludedTagPrefixes) {
+			tags = append(tags, cty.StringVal(tag))
+		}
+	}
+	if len(tags) > 0 {
+		body.SetAttributeValue("tags", cty.ListVal(tags))
+	}
+
+	if function.Telemetries != nil {
+		telemetries := map[string]cty.Value{}
+		setOptionalString(telemetries, "logs_telemetry_id", function.Telemetries.LogsTelemetryId)
+		setOptionalString(telemetries, "metrics_telemetry_id", function.Telemetries.MetricsTelemetryId)
+		setOptionalString(telemetries, "traces_telemetry_id", function.Telemetries.TracesTelemetryId)
+		if len(telemetries) > 0 {
+			body.SetAttributeValue("telemetries", cty.ObjectVal(telemetries))
+		}
+	}
+
+	if deployment != nil && len(deployment.DeploymentSpecifications) > 0 {
+		specs := make([]cty.Value, 0, len(deployment.DeploymentSpecifications))
+		for _, v := range deployment.DeploymentSpecifications {
+			spec := map[string]cty.Value{
+				"gpu_type":                cty.StringVal(v.Gpu),
+				"instance_type":           cty.StringVal(v.InstanceType),
+				"min_instances":           cty.NumberIntVal(int64(v.MinInstances)),
+				"max_instances":           cty.NumberIntVal(int64(v.MaxInstances)),
+				"max_request_concurrency": cty.NumberIntVal(int64(v.MaxRequestConcurrency)),
+			}
+			setOptionalString(spec, "backend", v.Backend)
+			setOptionalStrings(spec, "clusters", v.Clusters)
+			setOptionalStrings(spec, "regions", v.Regions)
+			if v.Configuration != nil {
+				configuration, _ := json.Marshal(v.Configuration)
+				spec["configuration"] = cty.StringVal(string(configuration))
+			}
+			specs = append(specs, cty.ObjectVal(spec))
+		}
+		body.SetAttributeValue("deployment_specifications", cty.TupleVal(specs))
+	}
+
+	return string(file.Bytes())
+}
+
+func setStringAttribute(body *hclwrite.Body, name string, value string) {
+	if value != "" {
+		body.SetAttributeValue(name, cty.StringVal(value))
+	}
+}
+
+func setOptionalString(object map[string]cty.Value, name string, value string) {
+	if value != "" {
+		object[name] = cty.StringVal(value)
+	}
+}
+
+func setOptionalStrings(object map[string]cty.Value, name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	items := make([]cty.Value, 0, len(values))
+	for _, v := range values {
+		items = append(items, cty.StringVal(v))
+	}
+	object[name] = cty.ListVal(items)
+}
+
+func artifactValue(name string, version string, uri string) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"name":    cty.StringVal(name),
+		"version": cty.StringVal(version),
+		"uri":     cty.StringVal(uri),
+	})
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestFunctionConfigHCL(t *testing.T) {
+	t.Parallel()
+
+	function := NvidiaCloudFunctionInfo{
+		ID:             mockFunctionID,
+		VersionID:      "f0cc4c95-108c-471a-b52c-a2bd5c0024c2",
+		Name:           "mock-function",
+		ContainerImage: "nvcr.io/mock-org/mock-image:1.0.0",
+		InferenceURL:   "/predict",
+		InferencePort:  8000,
+		APIBodyFormat:  "CUSTOM",
+		Health: &NvidiaCloudFunctionHealth{
+			Protocol:           "HTTP",
+			URI:                "/health",
+			Port:               8000,
+			Timeout:            "PT10S",
+			ExpectedStatusCode: 200,
+		},
+		HealthURI:            "/health",
+		ContainerEnvironment: []NvidiaCloudFunctionContainerEnvironment{{Key: "MOCK_KEY", Value: "MOCK_VALUE"}},
+		Tags:                 []string{"mock1", "version-label:v1"},
+	}
+	deployment := &NvidiaCloudFunctionDeployment{
+		DeploymentSpecifications: []NvidiaCloudFunctionDeploymentSpecification{
+			{
+				Gpu:                   "L40",
+				InstanceType:          "gl40_1.br20_2xlarge",
+				Backend:               "GFN",
+				MinInstances:          1,
+				MaxInstances:          2,
+				MaxRequestConcurrency: 3,
+			},
+		},
+	}
+
+	config := FunctionConfigHCL("mock", function, deployment, "version-label:")
+
+	file, diags := hclsyntax.ParseConfig([]byte(config), "main.tf", hcl.Pos{Line: 1, Column: 1})
+	assert.False(t, diags.HasErrors(), diags)
+
+	blocks := file.Body.(*hclsyntax.Body).Blocks
+	assert.Len(t, blocks, 2)
+
+	assert.Equal(t, "import", blocks[0].Type)
+	importID, diags := blocks[0].Body.Attributes["id"].Expr.Value(nil)
+	assert.False(t, diags.HasErrors(), diags)
+	assert.Equal(t, cty.StringVal(mockFunctionID+",f0cc4c95-108c-471a-b52c-a2bd5c0024c2"), importID)
+
+	assert.Equal(t, "resource", blocks[1].Type)
+	assert.Equal(t, []string{"ngc_cloud_function", "mock"}, blocks[1].Labels)
+
+	attributes := map[string]cty.Value{}
+	for name, attribute := range blocks[1].Body.Attributes {
+		value, diags := attribute.Expr.Value(nil)
+		assert.False(t, diags.HasErrors(), diags)
+		attributes[name] = value
+	}
+
+	assert.Equal(t, cty.StringVal("mock-function"), attributes["function_name"])
+	assert.Equal(t, cty.StringVal("nvcr.io/mock-org/mock-image:1.0.0"), attributes["container_image"])
+	assert.Equal(t, cty.StringVal("/predict"), attributes["inference_url"])
+	assert.True(t, attributes["inference_port"].Equals(cty.NumberIntVal(8000)).True())
+	assert.Equal(t, cty.StringVal("/health"), attributes["health"].GetAttr("uri"))
+	assert.Equal(t, cty.StringVal("MOCK_VALUE"), attributes["container_environment"].Index(cty.NumberIntVal(0)).GetAttr("value"))
+	assert.Equal(t, []cty.Value{cty.StringVal("mock1")}, attributes["tags"].AsValueSlice())
+	assert.Equal(t, cty.StringVal("GFN"), attributes["deployment_specifications"].Index(cty.NumberIntVal(0)).GetAttr("backend"))
+
+	// health_uri is derived from the health block and function_id would force a new version after import.
+	assert.NotContains(t, attributes, "health_uri")
+	assert.NotContains(t, attributes, "function_id")
+	assert.NotContains(t, attributes, "secrets")
+}
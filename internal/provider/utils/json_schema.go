@@ -0,0 +1,157 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ValidateJSONSchema checks a JSON document against a JSON schema and reports the path of the first
+// offending value, e.g. "$.image.tag". Only the keywords needed to catch mistakes in Helm values are
+// supported: type, enum, properties, required, additionalProperties and items, the others are ignored.
+func ValidateJSONSchema(schema string, document string) error {
+	var schemaObject map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &schemaObject); err != nil {
+		return fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	var documentValue interface{}
+	if err := json.Unmarshal([]byte(document), &documentValue); err != nil {
+		return fmt.Errorf("invalid JSON document: %w", err)
+	}
+
+	return validateJSONSchemaValue(schemaObject, documentValue, "$")
+}
+
+func validateJSONSchemaValue(schema map[string]interface{}, value interface{}, path string) error {
+	if err := validateJSONSchemaType(schema["type"], value, path); err != nil {
+		return err
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		if !slices.ContainsFunc(enum, func(v interface{}) bool { return reflect.DeepEqual(v, value) }) {
+			return fmt.Errorf("%s: value %s is not one of the allowed values", path, jsonText(value))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return validateJSONSchemaObject(schema, v, path)
+	case []interface{}:
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for i, item := range v {
+			if err := validateJSONSchemaValue(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateJSONSchemaObject(schema map[string]interface{}, value map[string]interface{}, path string) error {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, ok := value[name]; !ok {
+					return fmt.Errorf("%s: missing required property %q", path, name)
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	// Iterate in a stable order, so the same document always reports the same path.
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := path + "." + name
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			if err := validateJSONSchemaValue(property, value[name], propertyPath); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := properties[name]; ok {
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s: unknown property", propertyPath)
+			}
+		case map[string]interface{}:
+			if err := validateJSONSchemaValue(additional, value[name], propertyPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateJSONSchemaType(schemaType interface{}, value interface{}, path string) error {
+	var types []string
+	switch t := schemaType.(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				types = append(types, s)
+			}
+		}
+	default:
+		return nil
+	}
+
+	if slices.ContainsFunc(types, func(t string) bool { return jsonSchemaTypeMatches(t, value) }) {
+		return nil
+	}
+	return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(types, " or "), jsonText(value))
+}
+
+func jsonSchemaTypeMatches(schemaType string, value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return schemaType == "null"
+	case bool:
+		return schemaType == "boolean"
+	case float64:
+		return schemaType == "number" || (schemaType == "integer" && v == math.Trunc(v))
+	case string:
+		return schemaType == "string"
+	case []interface{}:
+		return schemaType == "array"
+	case map[string]interface{}:
+		return schemaType == "object"
+	default:
+		return false
+	}
+}
+
+func jsonText(value interface{}) string {
+	text, _ := json.Marshal(value)
+	return string(text)
+}
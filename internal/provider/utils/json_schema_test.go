@@ -0,0 +1,114 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := `{
+		"type": "object",
+		"required": ["image"],
+		"additionalProperties": false,
+		"properties": {
+			"image": {
+				"type": "object",
+				"properties": {
+					"repository": {"type": "string"},
+					"tag": {"type": "string"},
+					"pullPolicy": {"enum": ["Always", "IfNotPresent"]}
+				}
+			},
+			"replicaCount": {"type": "integer"},
+			"args": {"type": "array", "items": {"type": "string"}},
+			"env": {"type": "object", "additionalProperties": {"type": "string"}}
+		}
+	}`
+
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		wantErr  string
+	}{
+		{
+			name:     "Valid",
+			schema:   schema,
+			document: `{"image": {"repository": "nvcr.io/mock-org/mock-image", "tag": "1.0.0", "pullPolicy": "Always"}, "replicaCount": 1, "args": ["--mock"], "env": {"MOCK_KEY": "MOCK_VALUE"}}`,
+		},
+		{
+			name:     "UnknownProperty",
+			schema:   schema,
+			document: `{"image": {}, "replicas": 1}`,
+			wantErr:  "$.replicas: unknown property",
+		},
+		{
+			name:     "MissingRequiredProperty",
+			schema:   schema,
+			document: `{"replicaCount": 1}`,
+			wantErr:  `$: missing required property "image"`,
+		},
+		{
+			name:     "WrongType",
+			schema:   schema,
+			document: `{"image": {"tag": 1}}`,
+			wantErr:  "$.image.tag: expected string, got 1",
+		},
+		{
+			name:     "NotAnInteger",
+			schema:   schema,
+			document: `{"image": {}, "replicaCount": 1.5}`,
+			wantErr:  "$.replicaCount: expected integer, got 1.5",
+		},
+		{
+			name:     "NotAllowedValue",
+			schema:   schema,
+			document: `{"image": {"pullPolicy": "Never"}}`,
+			wantErr:  `$.image.pullPolicy: value "Never" is not one of the allowed values`,
+		},
+		{
+			name:     "ArrayItem",
+			schema:   schema,
+			document: `{"image": {}, "args": ["--mock", false]}`,
+			wantErr:  "$.args[1]: expected string, got false",
+		},
+		{
+			name:     "AdditionalPropertiesSchema",
+			schema:   schema,
+			document: `{"image": {}, "env": {"MOCK_KEY": 1}}`,
+			wantErr:  "$.env.MOCK_KEY: expected string, got 1",
+		},
+		{
+			name:     "InvalidSchema",
+			schema:   `{`,
+			document: `{}`,
+			wantErr:  "invalid JSON schema: unexpected end of JSON input",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSONSchema(tt.schema, tt.document)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -13,6 +13,7 @@ package utils
 import (
 	"net/http"
 	"sync"
+	"time"
 )
 
 type NGCClient struct {
@@ -20,7 +21,18 @@ type NGCClient struct {
 	NgcApiKey   string
 	NgcOrg      string
 	NgcTeam     string
-	HttpClient  *http.Client
+	ApiVersion  string
+	// InvokeEndpoint is the host serving the invocations, derived from NgcEndpoint when empty.
+	InvokeEndpoint string
+	HttpClient     *http.Client
+	RetryBudget    *RetryBudget
+	// RequestTimeout bounds every single HTTP call, zero disables it.
+	RequestTimeout time.Duration
+	// ProviderVersion is tagged on managed functions, empty when tag_provider_version is disabled.
+	ProviderVersion string
+	ExtraHeaders    map[string]string
+	// DeploymentDriftDetection reports a deployment deleted outside of Terraform as drift.
+	DeploymentDriftDetection bool
 }
 
 var nvcfClient *NVCFClient = nil
@@ -28,7 +40,18 @@ var nvcfClientOnce sync.Once
 
 func (c *NGCClient) NVCFClient() *NVCFClient {
 	nvcfClientOnce.Do(func() {
-		nvcfClient = &NVCFClient{c.NgcEndpoint, c.NgcApiKey, c.NgcOrg, c.NgcTeam, c.HttpClient}
+		nvcfClient = &NVCFClient{
+			NgcEndpoint:    c.NgcEndpoint,
+			NgcApiKey:      c.NgcApiKey,
+			NgcOrg:         c.NgcOrg,
+			NgcTeam:        c.NgcTeam,
+			ApiVersion:     c.ApiVersion,
+			InvokeEndpoint: c.InvokeEndpoint,
+			HttpClient:     c.HttpClient,
+			RetryBudget:    c.RetryBudget,
+			RequestTimeout: c.RequestTimeout,
+			ExtraHeaders:   c.ExtraHeaders,
+		}
 	})
 	return nvcfClient
 }
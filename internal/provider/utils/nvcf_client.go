@@ -17,8 +17,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -29,25 +33,173 @@ type NVCFClient struct {
 	NgcApiKey   string
 	NgcOrg      string
 	NgcTeam     string
-	HttpClient  *http.Client
+	// ApiVersion is the version prefix of the NVCF API paths, "v2" when empty.
+	ApiVersion string
+	// InvokeEndpoint is the host serving the invocations, derived from NgcEndpoint when empty.
+	InvokeEndpoint string
+	HttpClient     *http.Client
+	RetryBudget    *RetryBudget
+	// RequestTimeout bounds every single HTTP call, so a hung call fails fast and can be retried.
+	// Zero leaves the calls bounded by the operation context only.
+	RequestTimeout time.Duration
+	// ExtraHeaders are added to every request, e.g. a routing tag required by a gateway in front of NVCF.
+	ExtraHeaders map[string]string
+	// Clock drives the waits between retries and deployment polls, the wall clock when nil.
+	Clock Clock
 }
 
+// Clock abstracts the waits of the client, so tests can advance time deterministically.
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+type wallClock struct{}
+
+func (wallClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (c *NVCFClient) clock() Clock {
+	if c.Clock == nil {
+		return wallClock{}
+	}
+	return c.Clock
+}
+
+// WaitClock returns the clock of the client, for the retries done around its calls.
+func (c *NVCFClient) WaitClock() Clock {
+	return c.clock()
+}
+
+// deploymentPollInterval is the wait between two reads of a deploying function.
+const deploymentPollInterval = 60 * time.Second
+
+// deploymentDeletePollInterval is the wait between two reads of a terminating deployment.
+const deploymentDeletePollInterval = 10 * time.Second
+
+const defaultApiVersion = "v2"
+
+// listPageSize is the number of items asked for per page of a paged listing.
+const listPageSize = 100
+
 func (c *NVCFClient) NvcfEndpoint(context.Context) string {
+	apiVersion := c.ApiVersion
+	if apiVersion == "" {
+		apiVersion = defaultApiVersion
+	}
+
 	if c.NgcTeam == "" {
-		return fmt.Sprintf("%s/v2/orgs/%s", c.NgcEndpoint, c.NgcOrg)
+		return fmt.Sprintf("%s/%s/orgs/%s", c.NgcEndpoint, apiVersion, c.NgcOrg)
 	} else {
-		return fmt.Sprintf("%s/v2/orgs/%s/teams/%s", c.NgcEndpoint, c.NgcOrg, c.NgcTeam)
+		return fmt.Sprintf("%s/%s/orgs/%s/teams/%s", c.NgcEndpoint, apiVersion, c.NgcOrg, c.NgcTeam)
 	}
 }
 
+// nvcfURL builds the URL of an NVCF API under the org, and team when set, of the client.
+// Every endpoint must be built through it, so org-scoped keys never get a dangling team segment.
+func (c *NVCFClient) nvcfURL(ctx context.Context, pathSegments ...string) string {
+	return c.NvcfEndpoint(ctx) + "/nvcf/" + strings.Join(pathSegments, "/")
+}
+
+// Invocations are served by a dedicated NVCF host rather than the NGC management endpoint.
+var nvcfInvokeHosts = map[string]string{
+	"api.ngc.nvidia.com":     "https://api.nvcf.nvidia.com",
+	"api.stg.ngc.nvidia.com": "https://stg.api.nvcf.nvidia.com",
+}
+
+const defaultNvcfInvokeHost = "https://api.nvcf.nvidia.com"
+
+func (c *NVCFClient) NvcfInvokeHost(context.Context) string {
+	if c.InvokeEndpoint != "" {
+		return strings.TrimRight(c.InvokeEndpoint, "/")
+	}
+
+	u, err := url.Parse(c.NgcEndpoint)
+	if err != nil {
+		return defaultNvcfInvokeHost
+	}
+
+	if host, ok := nvcfInvokeHosts[u.Hostname()]; ok {
+		return host
+	}
+	return defaultNvcfInvokeHost
+}
+
 func (c *NVCFClient) HTTPClient(context.Context) *http.Client {
 	return c.HttpClient
 }
 
+// NotFoundError is returned when the NVCF API responds with 404 to a request not expecting it.
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+func IsNotFound(err error) bool {
+	var notFoundError *NotFoundError
+	return errors.As(err, &notFoundError)
+}
+
+// ConflictError is returned when the NVCF API answers a request with 409 Conflict.
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+func IsConflict(err error) bool {
+	var conflictError *ConflictError
+	return errors.As(err, &conflictError)
+}
+
+// NVCF doesn't always answer 409 for an existing deployment, only the message tells.
+const alreadyExistsDescription = "already exists"
+
+// ErrQuotaExceeded matches, with errors.Is, the errors returned when all the GPU instances allocated to the org are in use.
+var ErrQuotaExceeded = errors.New("insufficient GPU quota")
+
+// NVCF reports an exhausted GPU allocation only through the status description.
+const quotaExceededDescription = "all allocated gpu instances in use"
+
+type quotaExceededError struct {
+	message string
+}
+
+func (e *quotaExceededError) Error() string {
+	return e.message
+}
+
+func (e *quotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// ErrNoCredentials is returned by every request when the client has no API key to authenticate with.
+var ErrNoCredentials = errors.New("no credentials configured, set ngc_api_key in the provider configuration or the NGC_API_KEY environment variable")
+
+// Response statuses accepted as success, shared by the operations answering alike.
+var (
+	// successStatus is accepted by the operations answering with a body, i.e. reads, creates and most updates and deletes.
+	successStatus = map[int]bool{http.StatusOK: true}
+	// noContentSuccessStatus is accepted by the operations answering without body, e.g. deleting a function version or a telemetry.
+	noContentSuccessStatus = map[int]bool{http.StatusNoContent: true}
+	// readDeploymentSuccessStatus also accepts 404, which NVCF answers for a version that isn't deployed.
+	// The error body then decodes into an empty deployment, whose blank status tells callers nothing is deployed.
+	readDeploymentSuccessStatus = map[int]bool{http.StatusOK: true, http.StatusNotFound: true}
+)
+
 func (c *NVCFClient) sendRequest(ctx context.Context, requestURL string, method string, requestBody any, responseObject any, expectedStatusCode map[int]bool, queryParams map[string]string) error {
-	var request *http.Request
+	// Without a key NVCF answers with a bare 401, fail before sending anything.
+	if c.NgcApiKey == "" {
+		tflog.Error(ctx, fmt.Sprintf("no credentials configured for %s %s", method, requestURL))
+		return ErrNoCredentials
+	}
 
-	// Build URL with query parameters if provided
+	// Build URL with query parameters if provided, escaping their special characters, e.g. spaces, & and =.
 	finalURL := requestURL
 	if len(queryParams) > 0 {
 		u, err := url.Parse(requestURL)
@@ -64,6 +216,7 @@ func (c *NVCFClient) sendRequest(ctx context.Context, requestURL string, method
 		finalURL = u.String()
 	}
 
+	var payload []byte
 	if requestBody != nil {
 		payloadBuf := new(bytes.Buffer)
 		err := json.NewEncoder(payloadBuf).Encode(requestBody)
@@ -71,23 +224,47 @@ func (c *NVCFClient) sendRequest(ctx context.Context, requestURL string, method
 			tflog.Error(ctx, fmt.Sprintf("failed to parse request body %s", requestBody))
 			return err
 		}
-		request, _ = http.NewRequest(method, finalURL, payloadBuf)
-	} else {
-		request, _ = http.NewRequest(method, finalURL, http.NoBody)
+		payload = payloadBuf.Bytes()
 	}
 
-	request.Header.Set("Authorization", "Bearer "+c.NgcApiKey)
-	request.Header.Set("Content-Type", "application/json")
+	var response *http.Response
+	var body []byte
+	var err error
 
-	response, err := c.HttpClient.Do(request)
+	for attempt := 0; ; attempt++ {
+		response, body, err = c.doRequest(ctx, method, finalURL, payload)
+
+		if err == nil && expectedStatusCode[response.StatusCode] {
+			break
+		}
+
+		if !isRetryableRequest(method, response, err) {
+			break
+		}
+
+		delay := retryDelay(attempt)
+		if !c.RetryBudget.Reserve(delay) {
+			tflog.Warn(ctx, fmt.Sprintf("retry budget exhausted, giving up on %s %s", method, finalURL))
+			break
+		}
+
+		tflog.Warn(ctx, fmt.Sprintf("retrying %s %s in %s", method, finalURL, delay))
+		select {
+		case <-ctx.Done():
+			return errors.New("timeout occurred")
+		case <-c.clock().After(delay):
+		}
+	}
 
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("failed to send request to %s with method %s", finalURL, method))
 		return err
 	}
 
-	defer response.Body.Close()
-	body, _ := io.ReadAll(response.Body)
+	if len(body) > maxResponseBodySize {
+		tflog.Error(ctx, fmt.Sprintf("response body of %s %s is too large", method, finalURL))
+		return fmt.Errorf("response body exceeds the maximum size of %d bytes", maxResponseBodySize)
+	}
 
 	ctx = tflog.SetField(ctx, "response_status", response.Status)
 	ctx = tflog.SetField(ctx, "response_header", response.Header)
@@ -99,7 +276,8 @@ func (c *NVCFClient) sendRequest(ctx context.Context, requestURL string, method
 	if _, ok := expectedStatusCode[response.StatusCode]; !ok {
 		tflog.Error(ctx, "got unexpected response code")
 
-		// The unauthenticated response format is different with others
+		// The unauthenticated response format is different with others. The API key is static,
+		// there's no token to refresh, so the request isn't retried either.
 		if response.StatusCode == 401 {
 			tflog.Error(ctx, "unauthenticated error")
 			return errors.New("not authenticated")
@@ -114,14 +292,34 @@ func (c *NVCFClient) sendRequest(ctx context.Context, requestURL string, method
 			return fmt.Errorf("failed to parse error response body. Response body: %s", string(body))
 		}
 
-		if errResponseObject.RequestStatus.StatusDescription != "" {
-			return errors.New(errResponseObject.RequestStatus.StatusDescription)
-		} else {
-			return errors.New(errResponseObject.Detail)
+		errMessage := errResponseObject.Message(response.StatusCode)
+
+		if response.StatusCode == 404 {
+			return &NotFoundError{Message: errMessage}
+		}
+		if response.StatusCode == http.StatusConflict {
+			return &ConflictError{Message: errMessage}
+		}
+		if strings.Contains(strings.ToLower(errMessage), quotaExceededDescription) {
+			return &quotaExceededError{message: errMessage}
+		}
+		return errors.New(errMessage)
+	}
+
+	if checkEmbeddedError, _ := ctx.Value(embeddedErrorCheckKey{}).(bool); checkEmbeddedError {
+		if err := embeddedError(body, response.StatusCode); err != nil {
+			tflog.Error(ctx, "got error request status in successful response")
+			return err
 		}
 	}
 
 	if responseObject != nil {
+		// Proxies and SSO interstitials may answer with an HTML page instead of the API response.
+		if contentType := response.Header.Get("Content-Type"); len(body) > 0 && contentType != "" && !isJSONContentType(contentType) {
+			tflog.Error(ctx, "got unexpected response content type")
+			return fmt.Errorf("unexpected content type %s. Response body: %s", contentType, responseBodySnippet(body))
+		}
+
 		err = json.Unmarshal(body, responseObject)
 
 		if err != nil {
@@ -133,6 +331,67 @@ func (c *NVCFClient) sendRequest(ctx context.Context, requestURL string, method
 	return err
 }
 
+func (c *NVCFClient) doRequest(ctx context.Context, method string, requestURL string, payload []byte) (*http.Response, []byte, error) {
+	var request *http.Request
+
+	if c.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.RequestTimeout)
+		defer cancel()
+	}
+
+	if payload != nil {
+		request, _ = http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(payload))
+	} else {
+		request, _ = http.NewRequestWithContext(ctx, method, requestURL, http.NoBody)
+	}
+
+	for key, value := range c.ExtraHeaders {
+		if !IsReservedHeader(key) {
+			request.Header.Set(key, value)
+		}
+	}
+	request.Header.Set("Authorization", "Bearer "+c.NgcApiKey)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.HttpClient.Do(request)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer response.Body.Close()
+	// Read one byte past the limit, so sendRequest can tell an oversized body apart.
+	body, _ := io.ReadAll(io.LimitReader(response.Body, int64(maxResponseBodySize)+1))
+	return response, body, nil
+}
+
+// Headers set by the client itself, which extra headers can't override.
+var reservedHeaders = []string{"Authorization", "Content-Type"}
+
+func IsReservedHeader(key string) bool {
+	return slices.Contains(reservedHeaders, http.CanonicalHeaderKey(key))
+}
+
+var maxResponseBodySize = 10 * 1024 * 1024
+
+const responseBodySnippetSize = 256
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func responseBodySnippet(body []byte) string {
+	if len(body) > responseBodySnippetSize {
+		return string(body[:responseBodySnippetSize]) + "..."
+	}
+	return string(body)
+}
+
 // Helper function to build query parameters map.
 func BuildQueryParams(params ...string) map[string]string {
 	if len(params)%2 != 0 {
@@ -152,7 +411,7 @@ func BuildQueryParams(params ...string) map[string]string {
 func (c *NVCFClient) ListNvidiaCloudFunctionVersionsWithQuery(ctx context.Context, functionID string, limit int, offset int) (resp *ListNvidiaCloudFunctionVersionsResponse, err error) {
 	var listNvidiaCloudFunctionVersionsResponse ListNvidiaCloudFunctionVersionsResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/functions/" + functionID + "/versions"
+	requestURL := c.nvcfURL(ctx, "functions", functionID, "versions")
 
 	// Build query parameters
 	queryParams := BuildQueryParams(
@@ -160,61 +419,205 @@ func (c *NVCFClient) ListNvidiaCloudFunctionVersionsWithQuery(ctx context.Contex
 		"offset", fmt.Sprintf("%d", offset),
 	)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &listNvidiaCloudFunctionVersionsResponse, map[int]bool{200: true}, queryParams)
+	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &listNvidiaCloudFunctionVersionsResponse, successStatus, queryParams)
 	tflog.Debug(ctx, "List NVCF Function versions with query parameters")
 	return &listNvidiaCloudFunctionVersionsResponse, err
 }
 
+// ListNvidiaCloudFunctions lists the function versions owned by the organization.
+// The listing is paged through with limit and offset so large organizations get every version.
+// NVCF has no tag query parameter, so when tags are given only the versions carrying
+// all of them are kept.
+func (c *NVCFClient) ListNvidiaCloudFunctions(ctx context.Context, tags []string) (resp *ListNvidiaCloudFunctionsResponse, err error) {
+	var listNvidiaCloudFunctionsResponse ListNvidiaCloudFunctionsResponse
+
+	requestURL := c.nvcfURL(ctx, "functions")
+	listed := make(map[string]bool)
+
+	for offset := 0; ; offset += listPageSize {
+		var page ListNvidiaCloudFunctionsResponse
+		queryParams := BuildQueryParams(
+			"visibility", "private",
+			"limit", fmt.Sprintf("%d", listPageSize),
+			"offset", fmt.Sprintf("%d", offset),
+		)
+
+		err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &page, successStatus, queryParams)
+		tflog.Debug(ctx, "List NVCF Functions", map[string]interface{}{"offset": offset})
+		if err != nil {
+			return nil, err
+		}
+
+		newVersions := 0
+		for _, f := range page.Functions {
+			if !listed[f.VersionID] {
+				listed[f.VersionID] = true
+				listNvidiaCloudFunctionsResponse.Functions = append(listNvidiaCloudFunctionsResponse.Functions, f)
+				newVersions++
+			}
+		}
+
+		// A short page is the last one, a page without new versions means the listing isn't paged.
+		if len(page.Functions) < listPageSize || newVersions == 0 {
+			break
+		}
+	}
+
+	if len(tags) > 0 {
+		listNvidiaCloudFunctionsResponse.Functions = slices.DeleteFunc(listNvidiaCloudFunctionsResponse.Functions, func(f NvidiaCloudFunctionInfo) bool {
+			for _, tag := range tags {
+				if !slices.Contains(f.Tags, tag) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return &listNvidiaCloudFunctionsResponse, nil
+}
+
 // Function Management APIs.
 func (c *NVCFClient) CreateNvidiaCloudFunction(ctx context.Context, functionID string, req CreateNvidiaCloudFunctionRequest) (resp *CreateNvidiaCloudFunctionResponse, err error) {
 	var createNvidiaCloudFunctionResponse CreateNvidiaCloudFunctionResponse
 
 	var requestURL string
 	if functionID != "" {
-		requestURL = fmt.Sprintf("%s/nvcf/functions/%s/versions", c.NvcfEndpoint(ctx), functionID)
+		requestURL = c.nvcfURL(ctx, "functions", functionID, "versions")
 	} else {
-		requestURL = fmt.Sprintf("%s/nvcf/functions", c.NvcfEndpoint(ctx))
+		requestURL = c.nvcfURL(ctx, "functions")
 	}
 
-	err = c.sendRequest(ctx, requestURL, http.MethodPost, req, &createNvidiaCloudFunctionResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodPost, req, &createNvidiaCloudFunctionResponse, successStatus, nil)
 	tflog.Debug(ctx, "Create NVCF Function.")
 	return &createNvidiaCloudFunctionResponse, err
 }
 
+type embeddedErrorCheckKey struct{}
+
+// withEmbeddedErrorCheck returns a context whose successful responses are also checked for an error `requestStatus`.
+// Some gateways answer failures with 200 and the error in the body. The check is limited to the operations where
+// such a masked failure matters, a body without an error status code is never taken for an error.
+func withEmbeddedErrorCheck(ctx context.Context) context.Context {
+	return context.WithValue(ctx, embeddedErrorCheckKey{}, true)
+}
+
+// embeddedError returns the error carried by the `requestStatus` of a successful response body, if any.
+func embeddedError(body []byte, statusCode int) error {
+	var errResponseObject ErrorResponse
+	if json.Unmarshal(body, &errResponseObject) != nil {
+		return nil
+	}
+
+	requestStatus := errResponseObject.RequestStatus
+	if requestStatus.StatusCode == "" || requestStatus.StatusCode == "SUCCESS" {
+		return nil
+	}
+
+	errMessage := errResponseObject.Message(statusCode)
+	if strings.Contains(strings.ToLower(errMessage), quotaExceededDescription) {
+		return &quotaExceededError{message: errMessage}
+	}
+	return errors.New(errMessage)
+}
+
+type versionsCacheKey struct{}
+
+type versionsCache struct {
+	mu        sync.Mutex
+	responses map[string]*ListNvidiaCloudFunctionVersionsResponse
+}
+
+// WithVersionsCache returns a context under which the versions of each function are listed only once.
+// Listing the versions of a function with many versions is heavy, an operation reading them more than
+// once should use it. The cache is dropped along with the context at the end of the operation.
+func WithVersionsCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, versionsCacheKey{}, &versionsCache{responses: map[string]*ListNvidiaCloudFunctionVersionsResponse{}})
+}
+
 func (c *NVCFClient) ListNvidiaCloudFunctionVersions(ctx context.Context, functionID string) (resp *ListNvidiaCloudFunctionVersionsResponse, err error) {
+	cache, _ := ctx.Value(versionsCacheKey{}).(*versionsCache)
+	if cache != nil {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+
+		if cached, ok := cache.responses[functionID]; ok {
+			tflog.Debug(ctx, "List NVCF Function versions from cache")
+			return cached, nil
+		}
+	}
+
 	var listNvidiaCloudFunctionVersionsResponse ListNvidiaCloudFunctionVersionsResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/functions/" + functionID + "/versions"
+	requestURL := c.nvcfURL(ctx, "functions", functionID, "versions")
 
-	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &listNvidiaCloudFunctionVersionsResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &listNvidiaCloudFunctionVersionsResponse, successStatus, nil)
 	tflog.Debug(ctx, "List NVCF Function versions")
+
+	// Failures aren't cached, a later call retries them.
+	if cache != nil && err == nil {
+		cache.responses[functionID] = &listNvidiaCloudFunctionVersionsResponse
+	}
 	return &listNvidiaCloudFunctionVersionsResponse, err
 }
 
+// FindActiveNvidiaCloudFunctionVersion returns the single ACTIVE version of a function, the one serving its traffic.
+func (c *NVCFClient) FindActiveNvidiaCloudFunctionVersion(ctx context.Context, functionID string) (resp *NvidiaCloudFunctionInfo, err error) {
+	listNvidiaCloudFunctionVersionsResponse, err := c.ListNvidiaCloudFunctionVersions(ctx, functionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var activeVersionIds []string
+	for i, f := range listNvidiaCloudFunctionVersionsResponse.Functions {
+		if f.Status == "ACTIVE" {
+			resp = &listNvidiaCloudFunctionVersionsResponse.Functions[i]
+			activeVersionIds = append(activeVersionIds, f.VersionID)
+		}
+	}
+
+	switch len(activeVersionIds) {
+	case 0:
+		return nil, &NotFoundError{Message: fmt.Sprintf("function %s has no ACTIVE version", functionID)}
+	case 1:
+		return resp, nil
+	default:
+		return nil, fmt.Errorf("function %s has multiple ACTIVE versions: %s", functionID, strings.Join(activeVersionIds, ", "))
+	}
+}
+
 func (c *NVCFClient) UpdateNvidiaCloudFunctionMetadata(ctx context.Context, functionID string, functionVersionID string, req UpdateNvidiaCloudFunctionMetadataRequest) (resp *UpdateNvidiaCloudFunctionMetadataResponse, err error) {
 	var updateNvidiaCloudFunctionMetadataResponse UpdateNvidiaCloudFunctionMetadataResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/metadata/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL := c.nvcfURL(ctx, "metadata", "functions", functionID, "versions", functionVersionID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodPut, req, &updateNvidiaCloudFunctionMetadataResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodPut, req, &updateNvidiaCloudFunctionMetadataResponse, successStatus, nil)
 	tflog.Debug(ctx, "Update NVCF Function Metadata.")
 	return &updateNvidiaCloudFunctionMetadataResponse, err
 }
 
+// UpdateFunctionSecrets replaces the secrets of a function version in place, without creating a new version.
+func (c *NVCFClient) UpdateFunctionSecrets(ctx context.Context, functionID string, functionVersionID string, req UpdateNvidiaCloudFunctionSecretsRequest) error {
+	requestURL := c.nvcfURL(ctx, "secrets", "functions", functionID, "versions", functionVersionID)
+
+	err := c.sendRequest(ctx, requestURL, http.MethodPut, req, nil, noContentSuccessStatus, nil)
+	tflog.Debug(ctx, "Update NVCF Function Secrets.")
+	return err
+}
+
 func (c *NVCFClient) GetNvidiaCloudFunctionVersion(ctx context.Context, functionID string, functionVersionID string) (resp *GetNvidiaCloudFunctionVersionResponse, err error) {
 	var getNvidiaCloudFunctionVersionResponse GetNvidiaCloudFunctionVersionResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL := c.nvcfURL(ctx, "functions", functionID, "versions", functionVersionID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &getNvidiaCloudFunctionVersionResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &getNvidiaCloudFunctionVersionResponse, successStatus, nil)
 	tflog.Debug(ctx, "Get NVCF Function version")
 	return &getNvidiaCloudFunctionVersionResponse, err
 }
 
 func (c *NVCFClient) DeleteNvidiaCloudFunctionVersion(ctx context.Context, functionID string, functionVersionID string) (err error) {
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL := c.nvcfURL(ctx, "functions", functionID, "versions", functionVersionID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodDelete, nil, nil, map[int]bool{204: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodDelete, nil, nil, noContentSuccessStatus, nil)
 	tflog.Debug(ctx, "Delete Function Deployment")
 	return err
 }
@@ -222,20 +625,33 @@ func (c *NVCFClient) DeleteNvidiaCloudFunctionVersion(ctx context.Context, funct
 // Function Deployment APIs.
 func (c *NVCFClient) CreateNvidiaCloudFunctionDeployment(ctx context.Context, functionID string, functionVersionID string, req CreateNvidiaCloudFunctionDeploymentRequest) (resp *CreateNvidiaCloudFunctionDeploymentResponse, err error) {
 	var createNvidiaCloudFunctionDeploymentResponse CreateNvidiaCloudFunctionDeploymentResponse
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/deployments/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL := c.nvcfURL(ctx, "deployments", "functions", functionID, "versions", functionVersionID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodPost, req, &createNvidiaCloudFunctionDeploymentResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(withEmbeddedErrorCheck(ctx), requestURL, http.MethodPost, req, &createNvidiaCloudFunctionDeploymentResponse, successStatus, nil)
 	tflog.Debug(ctx, "Create Function Deployment")
+
+	// A prior apply which failed after creating the deployment left it behind, update it to the requested specifications instead.
+	if IsConflict(err) || (err != nil && strings.Contains(strings.ToLower(err.Error()), alreadyExistsDescription)) {
+		tflog.Info(ctx, "function deployment already exists, updating it", map[string]interface{}{"error": err.Error()})
+
+		updateNvidiaCloudFunctionDeploymentResponse, err := c.UpdateNvidiaCloudFunctionDeployment(ctx, functionID, functionVersionID,
+			UpdateNvidiaCloudFunctionDeploymentRequest{DeploymentSpecifications: req.DeploymentSpecifications})
+		if err != nil {
+			return &createNvidiaCloudFunctionDeploymentResponse, err
+		}
+		return &CreateNvidiaCloudFunctionDeploymentResponse{Deployment: updateNvidiaCloudFunctionDeploymentResponse.Deployment}, nil
+	}
 	return &createNvidiaCloudFunctionDeploymentResponse, err
 }
 
-// Deprecated: Use UpdateGpuSpecification instead.
+// UpdateNvidiaCloudFunctionDeployment replaces all deployment specifications in place.
+// Use UpdateGpuSpecification when only instance counts change.
 func (c *NVCFClient) UpdateNvidiaCloudFunctionDeployment(ctx context.Context, functionID string, functionVersionID string, req UpdateNvidiaCloudFunctionDeploymentRequest) (resp *UpdateNvidiaCloudFunctionDeploymentResponse, err error) {
 	var updateNvidiaCloudFunctionDeploymentResponse UpdateNvidiaCloudFunctionDeploymentResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/deployments/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL := c.nvcfURL(ctx, "deployments", "functions", functionID, "versions", functionVersionID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodPut, req, &updateNvidiaCloudFunctionDeploymentResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(withEmbeddedErrorCheck(ctx), requestURL, http.MethodPut, req, &updateNvidiaCloudFunctionDeploymentResponse, successStatus, nil)
 	tflog.Debug(ctx, "Update Function Deployment")
 	return &updateNvidiaCloudFunctionDeploymentResponse, err
 }
@@ -243,13 +659,121 @@ func (c *NVCFClient) UpdateNvidiaCloudFunctionDeployment(ctx context.Context, fu
 func (c *NVCFClient) UpdateGpuSpecification(ctx context.Context, deploymentID string, gpuSpecID string, req UpdateGpuSpecificationRequest) (resp *UpdateGpuSpecificationResponse, err error) {
 	var updateGpuSpecificationResponse UpdateGpuSpecificationResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/deployments/" + deploymentID + "/gpu-specifications/" + gpuSpecID
+	requestURL := c.nvcfURL(ctx, "deployments", deploymentID, "gpu-specifications", gpuSpecID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodPatch, req, &updateGpuSpecificationResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodPatch, req, &updateGpuSpecificationResponse, successStatus, nil)
 	tflog.Debug(ctx, "Update GPU Specification")
 	return &updateGpuSpecificationResponse, err
 }
 
+// ListClusterGroups returns the cluster groups the org can deploy to, along with their GPUs and instance types.
+func (c *NVCFClient) ListClusterGroups(ctx context.Context) (resp *ListNvidiaCloudFunctionClusterGroupsResponse, err error) {
+	var listNvidiaCloudFunctionClusterGroupsResponse ListNvidiaCloudFunctionClusterGroupsResponse
+
+	requestURL := c.nvcfURL(ctx, "clusterGroups")
+
+	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &listNvidiaCloudFunctionClusterGroupsResponse, successStatus, nil)
+	tflog.Debug(ctx, "List NVCF Cluster Groups")
+	return &listNvidiaCloudFunctionClusterGroupsResponse, err
+}
+
+// SelectInstanceType picks the most cost-effective instance type of a GPU, i.e. the one with the fewest GPUs,
+// then the fewest CPU cores. Only the cluster groups matching the backend, or holding one of the clusters, are
+// considered when either is set.
+func SelectInstanceType(clusterGroups []NvidiaCloudFunctionClusterGroup, gpu string, backend string, clusters []string) (string, error) {
+	var selected *NvidiaCloudFunctionInstanceType
+
+	for _, group := range clusterGroups {
+		if !clusterGroupMatches(group, backend, clusters) {
+			continue
+		}
+
+		for _, g := range group.Gpus {
+			if g.Name != gpu {
+				continue
+			}
+
+			for i, instanceType := range g.InstanceTypes {
+				if selected == nil || cheaperInstanceType(instanceType, *selected) {
+					selected = &g.InstanceTypes[i]
+				}
+			}
+		}
+	}
+
+	if selected == nil {
+		return "", fmt.Errorf("no instance type of GPU %s is available", gpu)
+	}
+	return selected.Name, nil
+}
+
+// AvailableInstanceTypes returns the instance types of a GPU the org can deploy to, restricted to the cluster
+// group of the backend when set.
+func AvailableInstanceTypes(clusterGroups []NvidiaCloudFunctionClusterGroup, gpu string, backend string) []string {
+	instanceTypes := make([]string, 0)
+	for _, group := range clusterGroups {
+		if !clusterGroupMatches(group, backend, nil) {
+			continue
+		}
+
+		for _, g := range group.Gpus {
+			if g.Name != gpu {
+				continue
+			}
+
+			for _, instanceType := range g.InstanceTypes {
+				if !slices.Contains(instanceTypes, instanceType.Name) {
+					instanceTypes = append(instanceTypes, instanceType.Name)
+				}
+			}
+		}
+	}
+	return instanceTypes
+}
+
+func clusterGroupMatches(group NvidiaCloudFunctionClusterGroup, backend string, clusters []string) bool {
+	if backend == "" && len(clusters) == 0 {
+		return true
+	}
+
+	if backend != "" && group.Name == backend {
+		return true
+	}
+
+	for _, cluster := range group.Clusters {
+		if slices.Contains(clusters, cluster.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func cheaperInstanceType(a NvidiaCloudFunctionInstanceType, b NvidiaCloudFunctionInstanceType) bool {
+	if a.GpuCount != b.GpuCount {
+		return a.GpuCount < b.GpuCount
+	}
+	if a.CPUCores != b.CPUCores {
+		return a.CPUCores < b.CPUCores
+	}
+	return a.Name < b.Name
+}
+
+// ScalesToZero tells whether no deployment specification keeps an instance running.
+func ScalesToZero(specs []NvidiaCloudFunctionDeploymentSpecification) bool {
+	if len(specs) == 0 {
+		return false
+	}
+
+	for _, spec := range specs {
+		if spec.MinInstances > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingDeploymentCompleted waits for the deployment to be ACTIVE. A deployment scaling to zero may also
+// settle INACTIVE while it has no instance, which is a success as well.
 func (c *NVCFClient) WaitingDeploymentCompleted(ctx context.Context, functionID string, functionVersionId string) error {
 	for {
 		readNvidiaCloudFunctionDeploymentResponse, err := c.ReadNvidiaCloudFunctionDeployment(ctx, functionID, functionVersionId)
@@ -260,25 +784,128 @@ func (c *NVCFClient) WaitingDeploymentCompleted(ctx context.Context, functionID
 
 		if readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus == "ACTIVE" {
 			return nil
+		} else if readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus == "INACTIVE" &&
+			ScalesToZero(readNvidiaCloudFunctionDeploymentResponse.Deployment.DeploymentSpecifications) {
+			return nil
 		} else if readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus == "DEPLOYING" {
 			select {
 			case <-ctx.Done():
 				return errors.New("timeout occurred")
-			case <-time.After(60 * time.Second):
+			case <-c.clock().After(deploymentPollInterval):
 				continue
 			}
 		} else {
-			return fmt.Errorf("unexpected status %s", readNvidiaCloudFunctionDeploymentResponse.Deployment.FunctionStatus)
+			deployment := readNvidiaCloudFunctionDeploymentResponse.Deployment
+			if reason := deploymentFailureReason(deployment.HealthInfo); reason != "" {
+				return fmt.Errorf("unexpected status %s: %s", deployment.FunctionStatus, reason)
+			}
+			return fmt.Errorf("unexpected status %s", deployment.FunctionStatus)
 		}
 	}
 }
 
+// WaitingMinInstancesActive waits until the function version has as many ACTIVE instances as the minimum of its
+// deployment specifications. NVCF reports the deployment ACTIVE as soon as a single instance is up.
+func (c *NVCFClient) WaitingMinInstancesActive(ctx context.Context, functionID string, functionVersionId string, specs []NvidiaCloudFunctionDeploymentSpecification) error {
+	minInstances := 0
+	for _, spec := range specs {
+		minInstances += spec.MinInstances
+	}
+
+	for {
+		getNvidiaCloudFunctionVersionResponse, err := c.GetNvidiaCloudFunctionVersion(ctx, functionID, functionVersionId)
+
+		if err != nil {
+			return err
+		}
+
+		activeInstances := 0
+		for _, instance := range getNvidiaCloudFunctionVersionResponse.Function.ActiveInstances {
+			if instance.InstanceStatus == "ACTIVE" {
+				activeInstances++
+			}
+		}
+
+		if activeInstances >= minInstances {
+			return nil
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("%d of %d instances active", activeInstances, minInstances))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout occurred, %d of %d instances active", activeInstances, minInstances)
+		case <-c.clock().After(deploymentPollInterval):
+		}
+	}
+}
+
+// WaitingDeploymentDeleted waits until a deleted deployment is gone. NVCF keeps the deployment terminating
+// for a while after the deletion was accepted, and rejects the deletion of its version meanwhile.
+func (c *NVCFClient) WaitingDeploymentDeleted(ctx context.Context, functionID string, functionVersionId string) error {
+	for {
+		readNvidiaCloudFunctionDeploymentResponse, err := c.ReadNvidiaCloudFunctionDeployment(ctx, functionID, functionVersionId)
+
+		if IsNotFound(err) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// NVCF answers with an empty deployment once it's gone.
+		if readNvidiaCloudFunctionDeploymentResponse.Deployment.DeploymentID == "" {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.New("timeout occurred")
+		case <-c.clock().After(deploymentDeletePollInterval):
+		}
+	}
+}
+
+// deploymentFailureReason extracts the errors NVCF reports in the health info of a deployment,
+// e.g. a failed image pull, prefixed with the GPU and instance type they occurred on.
+func deploymentFailureReason(healthInfo interface{}) string {
+	switch v := healthInfo.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		reasons := make([]string, 0, len(v))
+		for _, item := range v {
+			if reason := deploymentFailureReason(item); reason != "" {
+				reasons = append(reasons, reason)
+			}
+		}
+		return strings.Join(reasons, "; ")
+	case map[string]interface{}:
+		reason, ok := v["error"].(string)
+		if !ok || reason == "" {
+			return ""
+		}
+		gpu, _ := v["gpu"].(string)
+		instanceType, _ := v["instanceType"].(string)
+		if gpu != "" && instanceType != "" {
+			return fmt.Sprintf("%s/%s: %s", gpu, instanceType, reason)
+		}
+		return reason
+	default:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	}
+}
+
 func (c *NVCFClient) ReadNvidiaCloudFunctionDeployment(ctx context.Context, functionID string, functionVersionID string) (resp *ReadNvidiaCloudFunctionDeploymentResponse, err error) {
 	var readNvidiaCloudFunctionDeploymentResponse ReadNvidiaCloudFunctionDeploymentResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/deployments/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL := c.nvcfURL(ctx, "deployments", "functions", functionID, "versions", functionVersionID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &readNvidiaCloudFunctionDeploymentResponse, map[int]bool{200: true, 404: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &readNvidiaCloudFunctionDeploymentResponse, readDeploymentSuccessStatus, nil)
 	tflog.Debug(ctx, "Read Function Deployment")
 	return &readNvidiaCloudFunctionDeploymentResponse, err
 }
@@ -290,8 +917,8 @@ func (c *NVCFClient) DeleteNvidiaCloudFunctionDeployment(ctx context.Context, fu
 		"graceful", fmt.Sprintf("%t", graceful),
 	)
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/deployments/functions/" + functionID + "/versions/" + functionVersionID
-	err = c.sendRequest(ctx, requestURL, http.MethodDelete, nil, &deleteNvidiaCloudFunctionDeploymentResponse, map[int]bool{200: true}, queryParams)
+	requestURL := c.nvcfURL(ctx, "deployments", "functions", functionID, "versions", functionVersionID)
+	err = c.sendRequest(ctx, requestURL, http.MethodDelete, nil, &deleteNvidiaCloudFunctionDeploymentResponse, successStatus, queryParams)
 	tflog.Debug(ctx, "Delete Function Deployment")
 	return &deleteNvidiaCloudFunctionDeploymentResponse, err
 }
@@ -300,17 +927,17 @@ func (c *NVCFClient) DeleteNvidiaCloudFunctionDeployment(ctx context.Context, fu
 func (c *NVCFClient) AuthorizeAccountsToInvokeFunction(ctx context.Context, functionID string, functionVersionID string, req AuthorizeAccountsToInvokeFunctionRequest) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
 	var authorizeAccountsToInvokeFunctionResponse AuthorizeAccountsToInvokeFunctionResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/authorizations/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL := c.nvcfURL(ctx, "authorizations", "functions", functionID, "versions", functionVersionID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodPost, req, &authorizeAccountsToInvokeFunctionResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodPost, req, &authorizeAccountsToInvokeFunctionResponse, successStatus, nil)
 	tflog.Debug(ctx, "Authorize Accounts To Invoke Function")
 	return &authorizeAccountsToInvokeFunctionResponse, err
 }
 
 func (c *NVCFClient) UnAuthorizeAllExtraAccountsToInvokeFunction(ctx context.Context, functionID string, functionVersionID string) (err error) {
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/authorizations/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL := c.nvcfURL(ctx, "authorizations", "functions", functionID, "versions", functionVersionID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodDelete, nil, nil, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodDelete, nil, nil, successStatus, nil)
 	tflog.Debug(ctx, "Unauthorize All Extra Accounts To Invoke Function")
 	return err
 }
@@ -318,9 +945,9 @@ func (c *NVCFClient) UnAuthorizeAllExtraAccountsToInvokeFunction(ctx context.Con
 func (c *NVCFClient) GetFunctionAuthorization(ctx context.Context, functionID string, functionVersionID string) (resp *AuthorizeAccountsToInvokeFunctionResponse, err error) {
 	var authorizeAccountsToInvokeFunctionResponse AuthorizeAccountsToInvokeFunctionResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/authorizations/functions/" + functionID + "/versions/" + functionVersionID
+	requestURL := c.nvcfURL(ctx, "authorizations", "functions", functionID, "versions", functionVersionID)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &authorizeAccountsToInvokeFunctionResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &authorizeAccountsToInvokeFunctionResponse, successStatus, nil)
 	tflog.Debug(ctx, "Get Function Authorization")
 	return &authorizeAccountsToInvokeFunctionResponse, err
 }
@@ -329,9 +956,9 @@ func (c *NVCFClient) GetFunctionAuthorization(ctx context.Context, functionID st
 func (c *NVCFClient) CreateTelemetry(ctx context.Context, req CreateNvidiaCloudFunctionTelemetryRequest) (resp *CreateNvidiaCloudFunctionTelemetryResponse, err error) {
 	var telemetryResponse CreateNvidiaCloudFunctionTelemetryResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/telemetries"
+	requestURL := c.nvcfURL(ctx, "telemetries")
 
-	err = c.sendRequest(ctx, requestURL, http.MethodPost, req, &telemetryResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodPost, req, &telemetryResponse, successStatus, nil)
 	tflog.Debug(ctx, "Create Telemetry")
 	return &telemetryResponse, err
 }
@@ -339,9 +966,9 @@ func (c *NVCFClient) CreateTelemetry(ctx context.Context, req CreateNvidiaCloudF
 func (c *NVCFClient) GetTelemetry(ctx context.Context, telemetryId string) (resp *GetNvidiaCloudFunctionTelemetryResponse, err error) {
 	var telemetryResponse GetNvidiaCloudFunctionTelemetryResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/telemetries/" + telemetryId
+	requestURL := c.nvcfURL(ctx, "telemetries", telemetryId)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &telemetryResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &telemetryResponse, successStatus, nil)
 	tflog.Debug(ctx, "Get Telemetry")
 	return &telemetryResponse, err
 }
@@ -349,17 +976,42 @@ func (c *NVCFClient) GetTelemetry(ctx context.Context, telemetryId string) (resp
 func (c *NVCFClient) ListTelemetries(ctx context.Context) (resp *ListNvidiaCloudFunctionTelemetryResponse, err error) {
 	var listTelemetryResponse ListNvidiaCloudFunctionTelemetryResponse
 
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/telemetries"
+	requestURL := c.nvcfURL(ctx, "telemetries")
 
-	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &listTelemetryResponse, map[int]bool{200: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodGet, nil, &listTelemetryResponse, successStatus, nil)
 	tflog.Debug(ctx, "List Telemetries")
 	return &listTelemetryResponse, err
 }
 
+// FindTelemetryByName resolves a telemetry by its name, which is unique per secret but not enforced by NVCF.
+func (c *NVCFClient) FindTelemetryByName(ctx context.Context, name string) (resp *NvidiaCloudFunctionTelemetry, err error) {
+	listTelemetryResponse, err := c.ListTelemetries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matchedIds []string
+	for i, t := range listTelemetryResponse.Telemetries {
+		if t.Name == name {
+			resp = &listTelemetryResponse.Telemetries[i]
+			matchedIds = append(matchedIds, t.TelemetryId)
+		}
+	}
+
+	switch len(matchedIds) {
+	case 0:
+		return nil, &NotFoundError{Message: fmt.Sprintf("telemetry %s not found", name)}
+	case 1:
+		return resp, nil
+	default:
+		return nil, fmt.Errorf("telemetry name %s is ambiguous, matched ids: %s", name, strings.Join(matchedIds, ", "))
+	}
+}
+
 func (c *NVCFClient) DeleteTelemetry(ctx context.Context, telemetryId string) (err error) {
-	requestURL := c.NvcfEndpoint(ctx) + "/nvcf/telemetries/" + telemetryId
+	requestURL := c.nvcfURL(ctx, "telemetries", telemetryId)
 
-	err = c.sendRequest(ctx, requestURL, http.MethodDelete, nil, nil, map[int]bool{204: true}, nil)
+	err = c.sendRequest(ctx, requestURL, http.MethodDelete, nil, nil, noContentSuccessStatus, nil)
 	tflog.Debug(ctx, "Delete Telemetry")
 	return err
 }
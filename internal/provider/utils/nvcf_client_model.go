@@ -3,7 +3,15 @@
 
 package utils
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
 
 type RequestStatusModel struct {
 	StatusCode        string `json:"statusCode"`
@@ -21,6 +29,27 @@ type ErrorResponse struct {
 	Instance string `json:"instance"`
 }
 
+// Message returns the error message of the response. The RFC 7807 problem format, identified by
+// `type`, takes precedence over the `requestStatus` format. The HTTP status code is always included.
+func (e *ErrorResponse) Message(statusCode int) string {
+	var message string
+
+	switch {
+	case e.Type != "" && e.Title != "" && e.Detail != "":
+		message = e.Title + ": " + e.Detail
+	case e.Type != "" && (e.Title != "" || e.Detail != ""):
+		message = e.Title + e.Detail
+	case e.RequestStatus.StatusDescription != "":
+		message = e.RequestStatus.StatusDescription
+	case e.Detail != "":
+		message = e.Detail
+	default:
+		message = http.StatusText(statusCode)
+	}
+
+	return fmt.Sprintf("%s (status code %d)", message, statusCode)
+}
+
 type NvidiaCloudFunctionSecret struct {
 	Name  string      `json:"name"`
 	Value interface{} `json:"value"`
@@ -136,6 +165,8 @@ type NvidiaCloudFunctionInfo struct {
 	Telemetries             *NvidiaCloudFunctionTelemetryIds          `json:"telemetries,omitempty"`
 }
 
+// CreateNvidiaCloudFunctionRequest has no CPU or memory limits, the container gets the resources of the
+// instance type it's deployed on.
 type CreateNvidiaCloudFunctionRequest struct {
 	FunctionName         string                                    `json:"name"`
 	HelmChart            string                                    `json:"helmChart,omitempty"`
@@ -165,6 +196,10 @@ type ListNvidiaCloudFunctionVersionsResponse struct {
 	Functions []NvidiaCloudFunctionInfo `json:"functions"`
 }
 
+type ListNvidiaCloudFunctionsResponse struct {
+	Functions []NvidiaCloudFunctionInfo `json:"functions"`
+}
+
 type ListNvidiaCloudFunctionVersionsRequest struct {
 	FunctionID string `json:"name"`
 }
@@ -181,6 +216,12 @@ type UpdateNvidiaCloudFunctionMetadataResponse struct {
 	Function NvidiaCloudFunctionInfo `json:"function"`
 }
 
+type UpdateNvidiaCloudFunctionSecretsRequest struct {
+	Secrets []NvidiaCloudFunctionSecret `json:"secrets"`
+}
+
+// NvidiaCloudFunctionDeploymentSpecification has no autoscaling policy, the deployment API documents none and
+// NVCF scales the instances between MinInstances and MaxInstances on its own.
 type NvidiaCloudFunctionDeploymentSpecification struct {
 	GpuSpecificationID    string      `json:"gpuSpecificationId,omitempty"`
 	Gpu                   string      `json:"gpu"`
@@ -192,6 +233,70 @@ type NvidiaCloudFunctionDeploymentSpecification struct {
 	Configuration         interface{} `json:"configuration"`
 	Clusters              []string    `json:"clusters"`
 	Regions               []string    `json:"regions"`
+	// Extras keeps the fields NVCF returns but the provider doesn't know yet, they're sent back as is
+	// so replacing the specifications doesn't drop them.
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// deploymentSpecificationFields are the JSON names of the fields known to the provider, every other field is kept in Extras.
+var deploymentSpecificationFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(NvidiaCloudFunctionDeploymentSpecification{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+func (s *NvidiaCloudFunctionDeploymentSpecification) UnmarshalJSON(data []byte) error {
+	type deploymentSpecification NvidiaCloudFunctionDeploymentSpecification
+
+	var spec deploymentSpecification
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for name, value := range fields {
+		if deploymentSpecificationFields[name] {
+			continue
+		}
+		if spec.Extras == nil {
+			spec.Extras = make(map[string]json.RawMessage)
+		}
+		spec.Extras[name] = value
+	}
+
+	*s = NvidiaCloudFunctionDeploymentSpecification(spec)
+	return nil
+}
+
+func (s NvidiaCloudFunctionDeploymentSpecification) MarshalJSON() ([]byte, error) {
+	type deploymentSpecification NvidiaCloudFunctionDeploymentSpecification
+
+	data, err := json.Marshal(deploymentSpecification(s))
+	if err != nil || len(s.Extras) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for name, value := range s.Extras {
+		if !deploymentSpecificationFields[name] {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
 }
 
 type NvidiaCloudFunctionDeployment struct {
@@ -201,9 +306,68 @@ type NvidiaCloudFunctionDeployment struct {
 	NcaID                    string                                       `json:"ncaId"`
 	FunctionStatus           string                                       `json:"functionStatus"`
 	HealthInfo               interface{}                                  `json:"healthInfo"`
+	RequestQueueURL          string                                       `json:"requestQueueUrl,omitempty"`
 	DeploymentSpecifications []NvidiaCloudFunctionDeploymentSpecification `json:"deploymentSpecifications"`
 }
 
+// NvidiaCloudFunctionDeploymentHealth is the health NVCF reports for a GPU and instance type of a deployment.
+type NvidiaCloudFunctionDeploymentHealth struct {
+	SisRequestID string `json:"sisRequestId"`
+	Gpu          string `json:"gpu"`
+	Backend      string `json:"backend"`
+	InstanceType string `json:"instanceType"`
+	Error        string `json:"error"`
+}
+
+// Health returns the health info of the deployment as typed entries. A single entry is also accepted,
+// health info of any other shape, e.g. a bare message, yields no entry.
+func (d *NvidiaCloudFunctionDeployment) Health() []NvidiaCloudFunctionDeploymentHealth {
+	raw, err := json.Marshal(d.HealthInfo)
+	if err != nil {
+		return nil
+	}
+
+	var entries []NvidiaCloudFunctionDeploymentHealth
+	if json.Unmarshal(raw, &entries) == nil {
+		return entries
+	}
+
+	var entry NvidiaCloudFunctionDeploymentHealth
+	if json.Unmarshal(raw, &entry) == nil {
+		return []NvidiaCloudFunctionDeploymentHealth{entry}
+	}
+	return nil
+}
+
+// RequestQueueRegion returns the AWS region of the SQS queue receiving the async invocations, e.g. "us-west-2".
+func (d *NvidiaCloudFunctionDeployment) RequestQueueRegion() string {
+	u, err := url.Parse(d.RequestQueueURL)
+	if err != nil {
+		return ""
+	}
+
+	// SQS queue URLs look like https://sqs.<region>.amazonaws.com/<account>/<queue>.
+	hostParts := strings.Split(u.Hostname(), ".")
+	if len(hostParts) < 4 || hostParts[0] != "sqs" {
+		return ""
+	}
+	return hostParts[1]
+}
+
+// RequestQueueType returns "FIFO" or "STANDARD", following the SQS naming of FIFO queues.
+func (d *NvidiaCloudFunctionDeployment) RequestQueueType() string {
+	switch {
+	case d.RequestQueueURL == "":
+		return ""
+	case strings.HasSuffix(d.RequestQueueURL, ".fifo"):
+		return "FIFO"
+	default:
+		return "STANDARD"
+	}
+}
+
+// CreateNvidiaCloudFunctionDeploymentRequest only carries the specifications, NVCF deployments have no tags or labels
+// of their own. Billing and attribution rely on the tags of the function version the deployment belongs to.
 type CreateNvidiaCloudFunctionDeploymentRequest struct {
 	DeploymentSpecifications []NvidiaCloudFunctionDeploymentSpecification `json:"deploymentSpecifications"`
 }
@@ -212,6 +376,8 @@ type CreateNvidiaCloudFunctionDeploymentResponse struct {
 	Deployment NvidiaCloudFunctionDeployment `json:"deployment"`
 }
 
+// UpdateNvidiaCloudFunctionDeploymentRequest has no update strategy, NVCF decides how the instances
+// are replaced when the specifications change.
 type UpdateNvidiaCloudFunctionDeploymentRequest struct {
 	DeploymentSpecifications []NvidiaCloudFunctionDeploymentSpecification `json:"deploymentSpecifications"`
 }
@@ -256,3 +422,35 @@ type AuthorizeAccountsToInvokeFunctionResponseFunctionInfo struct {
 type AuthorizeAccountsToInvokeFunctionResponse struct {
 	Function AuthorizeAccountsToInvokeFunctionResponseFunctionInfo `json:"function"`
 }
+
+type NvidiaCloudFunctionInstanceType struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Default      bool   `json:"default"`
+	CPUCores     int    `json:"cpuCores"`
+	SystemMemory string `json:"systemMemory"`
+	GpuMemory    string `json:"gpuMemory"`
+	GpuCount     int    `json:"gpuCount"`
+}
+
+type NvidiaCloudFunctionClusterGroupGpu struct {
+	Name          string                            `json:"name"`
+	InstanceTypes []NvidiaCloudFunctionInstanceType `json:"instanceTypes"`
+}
+
+type NvidiaCloudFunctionCluster struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type NvidiaCloudFunctionClusterGroup struct {
+	ID       string                               `json:"id"`
+	Name     string                               `json:"name"`
+	NcaID    string                               `json:"ncaId"`
+	Gpus     []NvidiaCloudFunctionClusterGroupGpu `json:"gpus"`
+	Clusters []NvidiaCloudFunctionCluster         `json:"clusters"`
+}
+
+type ListNvidiaCloudFunctionClusterGroupsResponse struct {
+	ClusterGroups []NvidiaCloudFunctionClusterGroup `json:"clusterGroups"`
+}
@@ -17,14 +17,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -261,6 +265,49 @@ func TestNVCFClient_NvcfEndpoint(t *testing.T) {
 	}
 }
 
+func TestNVCFClient_NvcfInvokeHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		ngcEndpoint    string
+		invokeEndpoint string
+		want           string
+	}{
+		{
+			name:        "ProductionEndpoint",
+			ngcEndpoint: "https://api.ngc.nvidia.com",
+			want:        "https://api.nvcf.nvidia.com",
+		},
+		{
+			name:        "StagingEndpoint",
+			ngcEndpoint: "https://api.stg.ngc.nvidia.com",
+			want:        "https://stg.api.nvcf.nvidia.com",
+		},
+		{
+			name:        "UnknownEndpointFallbackToProduction",
+			ngcEndpoint: mockEndpoint,
+			want:        "https://api.nvcf.nvidia.com",
+		},
+		{
+			name:           "PinnedInvokeEndpoint",
+			ngcEndpoint:    "https://api.stg.ngc.nvidia.com",
+			invokeEndpoint: "https://mock-invoke.nvidia.com/",
+			want:           "https://mock-invoke.nvidia.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint:    tt.ngcEndpoint,
+				NgcOrg:         mockOrg,
+				InvokeEndpoint: tt.invokeEndpoint,
+			}
+			assert.Equal(t, tt.want, c.NvcfInvokeHost(context.Background()))
+		})
+	}
+}
+
 func TestNVCFClient_CreateNvidiaCloudFunction(t *testing.T) {
 	t.Parallel()
 
@@ -449,7 +496,7 @@ func TestNVCFClient_CreateNvidiaCloudFunction(t *testing.T) {
 			},
 			wantResp:   &CreateNvidiaCloudFunctionResponse{},
 			wantErr:    true,
-			wantErrMsg: mockErrorDetail,
+			wantErrMsg: mockErrorDetail + " (status code 400)",
 		},
 		{
 			name: "CreateHelmBasedNvidiaCloudFunctionVersion",
@@ -520,7 +567,7 @@ func TestNVCFClient_CreateNvidiaCloudFunction(t *testing.T) {
 			},
 			wantResp:   &CreateNvidiaCloudFunctionResponse{},
 			wantErr:    true,
-			wantErrMsg: mockErrorDetail,
+			wantErrMsg: mockErrorDetail + " (status code 500)",
 		},
 		{
 			name: "CreateHelmBasedNvidiaCloudFunctionVersionUnauthorized",
@@ -886,6 +933,102 @@ func TestNVCFClient_CreateNvidiaCloudFunctionDeployment(t *testing.T) {
 	}
 }
 
+func TestCreateNvidiaCloudFunctionDeploymentRequest_Attribution(t *testing.T) {
+	t.Parallel()
+
+	// The deployment is attributed through the tags of its function version, the request has no tags to propagate.
+	data, err := json.Marshal(CreateNvidiaCloudFunctionDeploymentRequest{
+		DeploymentSpecifications: []NvidiaCloudFunctionDeploymentSpecification{{Gpu: "L40"}},
+	})
+	assert.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, []string{"deploymentSpecifications"}, slices.Collect(maps.Keys(fields)))
+}
+
+func TestUpdateNvidiaCloudFunctionDeploymentRequest_Payload(t *testing.T) {
+	t.Parallel()
+
+	// An update strategy NVCF returns on a specification is sent back as is, the provider doesn't set one.
+	var spec NvidiaCloudFunctionDeploymentSpecification
+	assert.NoError(t, json.Unmarshal([]byte(`{"gpu": "L40", "updateStrategy": "ROLLING"}`), &spec))
+
+	data, err := json.Marshal(UpdateNvidiaCloudFunctionDeploymentRequest{
+		DeploymentSpecifications: []NvidiaCloudFunctionDeploymentSpecification{spec},
+	})
+	assert.NoError(t, err)
+
+	var payload struct {
+		DeploymentSpecifications []map[string]json.RawMessage `json:"deploymentSpecifications"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &payload))
+	assert.Len(t, payload.DeploymentSpecifications, 1)
+	assert.JSONEq(t, `"ROLLING"`, string(payload.DeploymentSpecifications[0]["updateStrategy"]))
+
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, []string{"deploymentSpecifications"}, slices.Collect(maps.Keys(fields)))
+}
+
+func TestNVCFClient_CreateNvidiaCloudFunctionDeploymentAlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	var req CreateNvidiaCloudFunctionDeploymentRequest
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{"deploymentSpecifications": [%s]}`, mockDeploymentSpecification)), &req))
+
+	tests := []struct {
+		name         string
+		conflictCode int
+		conflictBody string
+	}{
+		{
+			name:         "Conflict",
+			conflictCode: http.StatusConflict,
+			conflictBody: `{"requestStatus": {"statusCode": "INVALID_REQUEST", "statusDescription": "Deployment exists"}}`,
+		},
+		{
+			name:         "AlreadyExistsMessage",
+			conflictCode: http.StatusBadRequest,
+			conflictBody: `{"detail": "Deployment for function version already exists"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []string
+			var updateBody map[string]json.RawMessage
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+						requests = append(requests, r.Method+" "+r.URL.Path)
+						if r.Method == http.MethodPost {
+							return &http.Response{StatusCode: tt.conflictCode, Body: io.NopCloser(strings.NewReader(tt.conflictBody))}, nil
+						}
+						assert.NoError(t, json.NewDecoder(r.Body).Decode(&updateBody))
+						return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(mockFunctionDeploymentInfo))}, nil
+					}),
+				},
+			}
+
+			resp, err := c.CreateNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID, req)
+
+			assert.NoError(t, err)
+			deploymentPath := fmt.Sprintf("/v2/orgs/%s/teams/%s/nvcf/deployments/functions/%s/versions/%s", mockOrg, mockTeam, mockFunctionID, mockVersionID)
+			assert.Equal(t, []string{"POST " + deploymentPath, "PUT " + deploymentPath}, requests)
+			wantSpecs, err := json.Marshal(req.DeploymentSpecifications)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(wantSpecs), string(updateBody["deploymentSpecifications"]))
+			assert.Equal(t, "DEPLOYING", resp.Deployment.FunctionStatus)
+		})
+	}
+}
+
 func TestNVCFClient_UpdateNvidiaCloudFunctionDeployment(t *testing.T) {
 	t.Parallel()
 
@@ -1221,6 +1364,58 @@ func TestNVCFClient_WaitingDeploymentCompleted(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "WaitingDeploymentCompletedInactiveScaledToZero",
+			fields: fields{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(
+						t,
+						fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/deployments/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+						http.MethodGet,
+						nvcfRequestHeaders,
+						nil,
+						`{"deployment": {"functionStatus": "INACTIVE", "deploymentSpecifications": [{"gpu": "L40", "minInstances": 0, "maxInstances": 1}]}}`,
+						200,
+					),
+				},
+			},
+			args: args{
+				ctx:               context.Background(),
+				functionID:        mockFunctionID,
+				functionVersionID: mockVersionID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "WaitingDeploymentCompletedInactiveWithMinInstances",
+			fields: fields{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(
+						t,
+						fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/deployments/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+						http.MethodGet,
+						nvcfRequestHeaders,
+						nil,
+						`{"deployment": {"functionStatus": "INACTIVE", "deploymentSpecifications": [{"gpu": "L40", "minInstances": 1, "maxInstances": 1}]}}`,
+						200,
+					),
+				},
+			},
+			args: args{
+				ctx:               context.Background(),
+				functionID:        mockFunctionID,
+				functionVersionID: mockVersionID,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1238,6 +1433,14 @@ func TestNVCFClient_WaitingDeploymentCompleted(t *testing.T) {
 	}
 }
 
+func TestScalesToZero(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ScalesToZero([]NvidiaCloudFunctionDeploymentSpecification{{MinInstances: 0, MaxInstances: 2}, {MinInstances: 0, MaxInstances: 1}}))
+	assert.False(t, ScalesToZero([]NvidiaCloudFunctionDeploymentSpecification{{MinInstances: 0, MaxInstances: 2}, {MinInstances: 1, MaxInstances: 1}}))
+	assert.False(t, ScalesToZero(nil))
+}
+
 func TestNVCFClient_ReadNvidiaCloudFunctionDeployment(t *testing.T) {
 	t.Parallel()
 
@@ -1339,6 +1542,218 @@ func TestNVCFClient_ReadNvidiaCloudFunctionDeployment(t *testing.T) {
 	}
 }
 
+func TestNVCFClient_ReadNvidiaCloudFunctionDeploymentRequestQueue(t *testing.T) {
+	t.Parallel()
+
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		HttpClient: &http.Client{
+			Transport: GenerateHttpClientMockRoundTripper(
+				t,
+				fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/deployments/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+				http.MethodGet,
+				nvcfRequestHeaders,
+				nil,
+				mockFunctionDeploymentInfo,
+				200,
+			),
+		},
+	}
+
+	gotResp, err := c.ReadNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://sqs.us-west-2.amazonaws.com/052277528122/gdn-strap-dynamic_SfDTycz-Y81Iq7rCt_6cf20357-b6c9-459e-ae36-34b22319b7e4.fifo", gotResp.Deployment.RequestQueueURL)
+	assert.Equal(t, "us-west-2", gotResp.Deployment.RequestQueueRegion())
+	assert.Equal(t, "FIFO", gotResp.Deployment.RequestQueueType())
+
+	tests := []struct {
+		name       string
+		url        string
+		wantRegion string
+		wantType   string
+	}{
+		{name: "StandardQueue", url: "https://sqs.eu-central-1.amazonaws.com/052277528122/mock-queue", wantRegion: "eu-central-1", wantType: "STANDARD"},
+		{name: "NotSqsQueue", url: "https://queue.example.com/mock-queue", wantType: "STANDARD"},
+		{name: "NoQueue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := NvidiaCloudFunctionDeployment{RequestQueueURL: tt.url}
+			assert.Equal(t, tt.wantRegion, deployment.RequestQueueRegion())
+			assert.Equal(t, tt.wantType, deployment.RequestQueueType())
+		})
+	}
+}
+
+func TestNvidiaCloudFunctionDeployment_Health(t *testing.T) {
+	t.Parallel()
+
+	failedEntry := NvidiaCloudFunctionDeploymentHealth{
+		SisRequestID: "mock-sis-request-id",
+		Gpu:          "L40",
+		Backend:      "GFN",
+		InstanceType: "gl40_1.br20_2xlarge",
+		Error:        "Failed to pull image nvcr.io/mock-org/mock-image:1.0.0",
+	}
+	failedEntryRaw := `{"sisRequestId": "mock-sis-request-id", "gpu": "L40", "backend": "GFN", "instanceType": "gl40_1.br20_2xlarge", "error": "Failed to pull image nvcr.io/mock-org/mock-image:1.0.0"}`
+
+	tests := []struct {
+		name       string
+		healthInfo string
+		want       []NvidiaCloudFunctionDeploymentHealth
+	}{
+		{name: "Entries", healthInfo: "[" + failedEntryRaw + "]", want: []NvidiaCloudFunctionDeploymentHealth{failedEntry}},
+		{name: "SingleEntry", healthInfo: failedEntryRaw, want: []NvidiaCloudFunctionDeploymentHealth{failedEntry}},
+		{name: "Message", healthInfo: `"mock health message"`},
+		{name: "Missing", healthInfo: `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp ReadNvidiaCloudFunctionDeploymentResponse
+			assert.NoError(t, json.Unmarshal([]byte(`{"deployment": {"functionStatus": "FAILED", "healthInfo": `+tt.healthInfo+`}}`), &resp))
+			assert.Equal(t, tt.want, resp.Deployment.Health())
+		})
+	}
+}
+
+func TestNvidiaCloudFunctionDeploymentSpecification_ExtraFields(t *testing.T) {
+	t.Parallel()
+
+	var spec NvidiaCloudFunctionDeploymentSpecification
+	err := json.Unmarshal([]byte(`{
+		"gpu": "L40",
+		"backend": "GFN",
+		"instanceType": "gl40_1.br20_2xlarge",
+		"maxInstances": 2,
+		"minInstances": 1,
+		"preferredOrder": 3,
+		"storage": {"size": "10Gi"}
+	}`), &spec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "L40", spec.Gpu)
+	assert.Equal(t, map[string]json.RawMessage{
+		"preferredOrder": json.RawMessage(`3`),
+		"storage":        json.RawMessage(`{"size": "10Gi"}`),
+	}, spec.Extras)
+
+	// An update changing a known field sends the unknown ones back unchanged.
+	spec.MaxInstances = 3
+	data, err := json.Marshal(spec)
+	assert.NoError(t, err)
+
+	var fields map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, float64(3), fields["maxInstances"])
+	assert.Equal(t, float64(3), fields["preferredOrder"])
+	assert.Equal(t, map[string]interface{}{"size": "10Gi"}, fields["storage"])
+	assert.NotContains(t, fields, "Extras")
+
+	// Specifications without unknown fields are marshaled as before.
+	data, err = json.Marshal(NvidiaCloudFunctionDeploymentSpecification{Gpu: "L40"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"gpu": "L40", "backend": "", "instanceType": "", "maxInstances": 0, "minInstances": 0, "maxRequestConcurrency": 0, "configuration": null, "clusters": null, "regions": null}`, string(data))
+}
+
+var mockClusterGroupsInfo = `{"clusterGroups": [
+	{
+		"id": "mock-gfn-id",
+		"name": "GFN",
+		"gpus": [
+			{"name": "L40", "instanceTypes": [
+				{"name": "gl40_2.br20_4xlarge", "gpuCount": 2, "cpuCores": 32},
+				{"name": "gl40_1.br20_2xlarge", "gpuCount": 1, "cpuCores": 16},
+				{"name": "gl40_1.br20_4xlarge", "gpuCount": 1, "cpuCores": 32}
+			]}
+		],
+		"clusters": [{"id": "mock-gfn-cluster-id", "name": "mock-gfn-cluster"}]
+	},
+	{
+		"id": "mock-byoc-id",
+		"name": "mock-byoc",
+		"gpus": [
+			{"name": "L40", "instanceTypes": [{"name": "BYOC.L40.GPU.x1", "gpuCount": 1, "cpuCores": 8}]},
+			{"name": "H100", "instanceTypes": [{"name": "BYOC.H100.GPU.x8", "gpuCount": 8, "cpuCores": 96}]}
+		],
+		"clusters": [{"id": "mock-byoc-cluster-id", "name": "mock-byoc-cluster"}]
+	}
+]}`
+
+func TestNVCFClient_ListClusterGroups(t *testing.T) {
+	t.Parallel()
+
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		HttpClient: &http.Client{
+			Transport: GenerateHttpClientMockRoundTripper(
+				t,
+				fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/clusterGroups", mockEndpoint, mockOrg, mockTeam),
+				http.MethodGet,
+				nvcfRequestHeaders,
+				nil,
+				mockClusterGroupsInfo,
+				200,
+			),
+		},
+	}
+
+	gotResp, err := c.ListClusterGroups(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, gotResp.ClusterGroups, 2)
+	assert.Equal(t, "GFN", gotResp.ClusterGroups[0].Name)
+	assert.Equal(t, NvidiaCloudFunctionInstanceType{Name: "gl40_1.br20_2xlarge", GpuCount: 1, CPUCores: 16}, gotResp.ClusterGroups[0].Gpus[0].InstanceTypes[1])
+	assert.Equal(t, "mock-byoc-cluster", gotResp.ClusterGroups[1].Clusters[0].Name)
+}
+
+func TestSelectInstanceType(t *testing.T) {
+	t.Parallel()
+
+	var clusterGroups ListNvidiaCloudFunctionClusterGroupsResponse
+	assert.NoError(t, json.Unmarshal([]byte(mockClusterGroupsInfo), &clusterGroups))
+
+	tests := []struct {
+		name     string
+		gpu      string
+		backend  string
+		clusters []string
+		want     string
+		wantErr  bool
+	}{
+		{name: "AnyClusterGroup", gpu: "L40", want: "BYOC.L40.GPU.x1"},
+		{name: "Backend", gpu: "L40", backend: "GFN", want: "gl40_1.br20_2xlarge"},
+		{name: "Clusters", gpu: "L40", clusters: []string{"mock-gfn-cluster"}, want: "gl40_1.br20_2xlarge"},
+		{name: "GpuOfOtherClusterGroup", gpu: "H100", backend: "GFN", wantErr: true},
+		{name: "UnknownGpu", gpu: "A100", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectInstanceType(clusterGroups.ClusterGroups, tt.gpu, tt.backend, tt.clusters)
+
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAvailableInstanceTypes(t *testing.T) {
+	t.Parallel()
+
+	var clusterGroups ListNvidiaCloudFunctionClusterGroupsResponse
+	assert.NoError(t, json.Unmarshal([]byte(mockClusterGroupsInfo), &clusterGroups))
+
+	assert.Equal(t, []string{"gl40_2.br20_4xlarge", "gl40_1.br20_2xlarge", "gl40_1.br20_4xlarge", "BYOC.L40.GPU.x1"}, AvailableInstanceTypes(clusterGroups.ClusterGroups, "L40", ""))
+	assert.Equal(t, []string{"BYOC.H100.GPU.x8"}, AvailableInstanceTypes(clusterGroups.ClusterGroups, "H100", "mock-byoc"))
+	assert.Empty(t, AvailableInstanceTypes(clusterGroups.ClusterGroups, "H100", "GFN"))
+}
+
 func TestNVCFClient_DeleteNvidiaCloudFunctionDeployment(t *testing.T) {
 	t.Parallel()
 
@@ -1546,30 +1961,223 @@ func TestSendRequestWithQueryParams(t *testing.T) {
 	}
 }
 
-// Test that requests work without query parameters (backward compatibility)
-func TestSendRequestWithoutQueryParams(t *testing.T) {
-	// Create a mock response
-	mockResponse := &http.Response{
-		StatusCode: 200,
-		Header:     make(http.Header),
-		Body:       io.NopCloser(strings.NewReader(`{"success": true}`)),
+// Test that query parameter values with special characters are escaped
+func TestSendRequestWithQueryParamsEncoding(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestURL   string
+		queryParams  map[string]string
+		wantRawQuery string
+	}{
+		{
+			name:         "Space",
+			requestURL:   "https://api.ngc.nvidia.com/v2/orgs/test-org/nvcf/functions",
+			queryParams:  BuildQueryParams("name", "my function"),
+			wantRawQuery: "name=my+function",
+		},
+		{
+			name:         "AmpersandAndEquals",
+			requestURL:   "https://api.ngc.nvidia.com/v2/orgs/test-org/nvcf/functions",
+			queryParams:  BuildQueryParams("name", "a&b=c", "limit", "10"),
+			wantRawQuery: "limit=10&name=a%26b%3Dc",
+		},
+		{
+			name:         "PercentAndNonASCII",
+			requestURL:   "https://api.ngc.nvidia.com/v2/orgs/test-org/nvcf/functions",
+			queryParams:  BuildQueryParams("tag", "100%", "name", "füñ"),
+			wantRawQuery: "name=f%C3%BC%C3%B1&tag=100%25",
+		},
+		{
+			name:         "SpecialKey",
+			requestURL:   "https://api.ngc.nvidia.com/v2/orgs/test-org/nvcf/functions",
+			queryParams:  BuildQueryParams("filter[name]", "mock"),
+			wantRawQuery: "filter%5Bname%5D=mock",
+		},
+		{
+			name:         "ExistingQuery",
+			requestURL:   "https://api.ngc.nvidia.com/v2/orgs/test-org/nvcf/functions?visibility=private",
+			queryParams:  BuildQueryParams("name", "my function"),
+			wantRawQuery: "name=my+function&visibility=private",
+		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRT := &MockRoundTripper{Response: &http.Response{
+				StatusCode: 200,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(`{"success": true}`)),
+			}}
+			client := &NVCFClient{
+				NgcEndpoint: "https://api.ngc.nvidia.com",
+				NgcApiKey:   "test-key",
+				NgcOrg:      "test-org",
+				HttpClient:  &http.Client{Transport: mockRT},
+			}
 
-	// Create a mock round tripper
-	mockRT := &MockRoundTripper{Response: mockResponse}
+			err := client.sendRequest(context.Background(), tt.requestURL, http.MethodGet, nil, nil, map[int]bool{200: true}, tt.queryParams)
 
-	// Create NVCF client with mock HTTP client
-	client := &NVCFClient{
-		NgcEndpoint: "https://api.ngc.nvidia.com",
-		NgcApiKey:   "test-key",
-		NgcOrg:      "test-org",
-		NgcTeam:     "",
-		HttpClient: &http.Client{
-			Transport: mockRT,
-		},
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRawQuery, mockRT.Request.URL.RawQuery)
+			// The values come back unchanged once decoded.
+			for key, value := range tt.queryParams {
+				assert.Equal(t, value, mockRT.Request.URL.Query().Get(key))
+			}
+		})
 	}
+}
 
-	ctx := context.Background()
+func TestListNvidiaCloudFunctions(t *testing.T) {
+	functions := `{"functions": [
+		{"id": "fn-1", "versionId": "v-1", "tags": ["team:ml", "env:prod"]},
+		{"id": "fn-2", "versionId": "v-2", "tags": ["team:ml", "env:dev"]},
+		{"id": "fn-3", "versionId": "v-3"}
+	]}`
+
+	tests := []struct {
+		name    string
+		tags    []string
+		wantIDs []string
+	}{
+		{
+			name:    "NoTags",
+			wantIDs: []string{"fn-1", "fn-2", "fn-3"},
+		},
+		{
+			name:    "SingleTag",
+			tags:    []string{"team:ml"},
+			wantIDs: []string{"fn-1", "fn-2"},
+		},
+		{
+			name:    "AllTagsRequired",
+			tags:    []string{"team:ml", "env:prod"},
+			wantIDs: []string{"fn-1"},
+		},
+		{
+			name: "NoMatch",
+			tags: []string{"team:ml", "env:staging"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRT := &MockRoundTripper{Response: &http.Response{
+				StatusCode: 200,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(functions)),
+			}}
+			client := &NVCFClient{
+				NgcEndpoint: "https://api.ngc.nvidia.com",
+				NgcApiKey:   "test-key",
+				NgcOrg:      "test-org",
+				HttpClient:  &http.Client{Transport: mockRT},
+			}
+
+			resp, err := client.ListNvidiaCloudFunctions(context.Background(), tt.tags)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.MethodGet, mockRT.Request.Method)
+			assert.Equal(t, "/v2/orgs/test-org/nvcf/functions", mockRT.Request.URL.Path)
+			assert.Equal(t, url.Values{"visibility": {"private"}, "limit": {"100"}, "offset": {"0"}}, mockRT.Request.URL.Query())
+
+			var ids []string
+			for _, f := range resp.Functions {
+				ids = append(ids, f.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestListNvidiaCloudFunctions_Pages(t *testing.T) {
+	t.Parallel()
+
+	page := func(from int, to int) string {
+		functions := make([]string, 0, to-from)
+		for i := from; i < to; i++ {
+			functions = append(functions, fmt.Sprintf(`{"id": "fn-%[1]d", "versionId": "v-%[1]d"}`, i))
+		}
+		return `{"functions": [` + strings.Join(functions, ",") + `]}`
+	}
+
+	tests := []struct {
+		name        string
+		pages       map[string]string
+		wantOffsets []string
+		wantCount   int
+	}{
+		{
+			name:        "ShortLastPage",
+			pages:       map[string]string{"0": page(0, 100), "100": page(100, 101)},
+			wantOffsets: []string{"0", "100"},
+			wantCount:   101,
+		},
+		{
+			name:        "EmptyLastPage",
+			pages:       map[string]string{"0": page(0, 100), "100": page(0, 0)},
+			wantOffsets: []string{"0", "100"},
+			wantCount:   100,
+		},
+		{
+			// The whole listing is answered whatever the offset.
+			name:        "NotPaged",
+			pages:       map[string]string{"0": page(0, 100), "100": page(0, 100)},
+			wantOffsets: []string{"0", "100"},
+			wantCount:   100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotOffsets []string
+			client := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					offset := req.URL.Query().Get("offset")
+					gotOffsets = append(gotOffsets, offset)
+					assert.Equal(t, "100", req.URL.Query().Get("limit"))
+					return &http.Response{
+						StatusCode: 200,
+						Header:     make(http.Header),
+						Body:       io.NopCloser(strings.NewReader(tt.pages[offset])),
+					}, nil
+				})},
+			}
+
+			resp, err := client.ListNvidiaCloudFunctions(context.Background(), nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOffsets, gotOffsets)
+			assert.Len(t, resp.Functions, tt.wantCount)
+		})
+	}
+}
+
+// Test that requests work without query parameters (backward compatibility)
+func TestSendRequestWithoutQueryParams(t *testing.T) {
+	// Create a mock response
+	mockResponse := &http.Response{
+		StatusCode: 200,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(`{"success": true}`)),
+	}
+
+	// Create a mock round tripper
+	mockRT := &MockRoundTripper{Response: mockResponse}
+
+	// Create NVCF client with mock HTTP client
+	client := &NVCFClient{
+		NgcEndpoint: "https://api.ngc.nvidia.com",
+		NgcApiKey:   "test-key",
+		NgcOrg:      "test-org",
+		NgcTeam:     "",
+		HttpClient: &http.Client{
+			Transport: mockRT,
+		},
+	}
+
+	ctx := context.Background()
 
 	// Make a request without query parameters (nil)
 	err := client.sendRequest(
@@ -1745,7 +2353,7 @@ func TestNVCFClient_CreateTelemetry(t *testing.T) {
 			},
 			wantResp:   &CreateNvidiaCloudFunctionTelemetryResponse{},
 			wantErr:    true,
-			wantErrMsg: mockErrorDetail,
+			wantErrMsg: mockErrorDetail + " (status code 400)",
 		},
 	}
 	for _, tt := range tests {
@@ -2351,6 +2959,39 @@ func TestNVCFClient_GetFunctionAuthorization(t *testing.T) {
 	}
 }
 
+func TestNVCFClient_UpdateFunctionSecrets(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod, gotPath, gotBody string
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			gotMethod, gotPath, gotBody = req.Method, req.URL.Path, string(body)
+			return &http.Response{
+				StatusCode: 204,
+				Header:     make(http.Header),
+				Body:       http.NoBody,
+			}, nil
+		})},
+	}
+
+	err := c.UpdateFunctionSecrets(context.Background(), mockFunctionID, mockVersionID, UpdateNvidiaCloudFunctionSecretsRequest{
+		Secrets: []NvidiaCloudFunctionSecret{
+			{Name: "mock-api-key", Value: "mock-rotated-value"},
+			{Name: "mock-json-secret", Value: map[string]interface{}{"key": "value"}},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, gotMethod)
+	assert.Equal(t, fmt.Sprintf("/v2/orgs/%s/teams/%s/nvcf/secrets/functions/%s/versions/%s", mockOrg, mockTeam, mockFunctionID, mockVersionID), gotPath)
+	assert.JSONEq(t, `{"secrets":[{"name":"mock-api-key","value":"mock-rotated-value"},{"name":"mock-json-secret","value":{"key":"value"}}]}`, gotBody)
+}
+
 func TestNVCFClient_GetNvidiaCloudFunctionVersion(t *testing.T) {
 	t.Parallel()
 
@@ -2371,11 +3012,12 @@ func TestNVCFClient_GetNvidiaCloudFunctionVersion(t *testing.T) {
 		functionVersionID string
 	}
 	tests := []struct {
-		name     string
-		fields   fields
-		args     args
-		wantResp *GetNvidiaCloudFunctionVersionResponse
-		wantErr  bool
+		name         string
+		fields       fields
+		args         args
+		wantResp     *GetNvidiaCloudFunctionVersionResponse
+		wantErr      bool
+		wantNotFound bool
 	}{
 		{
 			name: "GetNvidiaCloudFunctionVersionSuccess",
@@ -2428,8 +3070,9 @@ func TestNVCFClient_GetNvidiaCloudFunctionVersion(t *testing.T) {
 				functionID:        mockFunctionID,
 				functionVersionID: mockVersionID,
 			},
-			wantResp: &GetNvidiaCloudFunctionVersionResponse{},
-			wantErr:  true,
+			wantResp:     &GetNvidiaCloudFunctionVersionResponse{},
+			wantErr:      true,
+			wantNotFound: true,
 		},
 	}
 	for _, tt := range tests {
@@ -2446,6 +3089,9 @@ func TestNVCFClient_GetNvidiaCloudFunctionVersion(t *testing.T) {
 				t.Errorf("NVCFClient.GetNvidiaCloudFunctionVersion() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if IsNotFound(err) != tt.wantNotFound {
+				t.Errorf("IsNotFound() = %v, wantNotFound %v", IsNotFound(err), tt.wantNotFound)
+			}
 			if !reflect.DeepEqual(gotResp, tt.wantResp) {
 				t.Errorf("NVCFClient.GetNvidiaCloudFunctionVersion() = %v, want %v", gotResp, tt.wantResp)
 			}
@@ -2720,7 +3366,7 @@ func TestNVCFClient_ErrorResponseWithDetail(t *testing.T) {
 				},
 			},
 			wantErr:    true,
-			wantErrMsg: "Invalid function configuration",
+			wantErrMsg: "Bad Request: Invalid function configuration (status code 400)",
 		},
 	}
 	for _, tt := range tests {
@@ -2775,3 +3421,1257 @@ func TestNVCFClient_EndpointWithoutTeam(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, &getFunctionVersionMockResp, gotResp)
 }
+
+func TestNVCFClient_EndpointScope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockDeploymentID := "mock-deployment-id"
+	mockGpuSpecID := "mock-gpu-spec-id"
+	mockTelemetryID := "mock-telemetry-id"
+
+	tests := []struct {
+		name         string
+		responseCode int
+		call         func(c *NVCFClient) error
+		wantPath     string
+	}{
+		{
+			name:         "CreateNvidiaCloudFunction",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.CreateNvidiaCloudFunction(ctx, "", CreateNvidiaCloudFunctionRequest{})
+				return err
+			},
+			wantPath: "/nvcf/functions",
+		},
+		{
+			name:         "CreateNvidiaCloudFunctionVersion",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.CreateNvidiaCloudFunction(ctx, mockFunctionID, CreateNvidiaCloudFunctionRequest{})
+				return err
+			},
+			wantPath: "/nvcf/functions/" + mockFunctionID + "/versions",
+		},
+		{
+			name:         "ListNvidiaCloudFunctionVersions",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.ListNvidiaCloudFunctionVersions(ctx, mockFunctionID)
+				return err
+			},
+			wantPath: "/nvcf/functions/" + mockFunctionID + "/versions",
+		},
+		{
+			name:         "ListNvidiaCloudFunctionVersionsWithQuery",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.ListNvidiaCloudFunctionVersionsWithQuery(ctx, mockFunctionID, 10, 0)
+				return err
+			},
+			wantPath: "/nvcf/functions/" + mockFunctionID + "/versions",
+		},
+		{
+			name:         "UpdateNvidiaCloudFunctionMetadata",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.UpdateNvidiaCloudFunctionMetadata(ctx, mockFunctionID, mockVersionID, UpdateNvidiaCloudFunctionMetadataRequest{})
+				return err
+			},
+			wantPath: "/nvcf/metadata/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "GetNvidiaCloudFunctionVersion",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.GetNvidiaCloudFunctionVersion(ctx, mockFunctionID, mockVersionID)
+				return err
+			},
+			wantPath: "/nvcf/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "DeleteNvidiaCloudFunctionVersion",
+			responseCode: 204,
+			call: func(c *NVCFClient) error {
+				return c.DeleteNvidiaCloudFunctionVersion(ctx, mockFunctionID, mockVersionID)
+			},
+			wantPath: "/nvcf/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "CreateNvidiaCloudFunctionDeployment",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.CreateNvidiaCloudFunctionDeployment(ctx, mockFunctionID, mockVersionID, CreateNvidiaCloudFunctionDeploymentRequest{})
+				return err
+			},
+			wantPath: "/nvcf/deployments/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "UpdateNvidiaCloudFunctionDeployment",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.UpdateNvidiaCloudFunctionDeployment(ctx, mockFunctionID, mockVersionID, UpdateNvidiaCloudFunctionDeploymentRequest{})
+				return err
+			},
+			wantPath: "/nvcf/deployments/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "UpdateGpuSpecification",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.UpdateGpuSpecification(ctx, mockDeploymentID, mockGpuSpecID, UpdateGpuSpecificationRequest{})
+				return err
+			},
+			wantPath: "/nvcf/deployments/" + mockDeploymentID + "/gpu-specifications/" + mockGpuSpecID,
+		},
+		{
+			name:         "ReadNvidiaCloudFunctionDeployment",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.ReadNvidiaCloudFunctionDeployment(ctx, mockFunctionID, mockVersionID)
+				return err
+			},
+			wantPath: "/nvcf/deployments/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "DeleteNvidiaCloudFunctionDeployment",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.DeleteNvidiaCloudFunctionDeployment(ctx, mockFunctionID, mockVersionID, false)
+				return err
+			},
+			wantPath: "/nvcf/deployments/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "AuthorizeAccountsToInvokeFunction",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.AuthorizeAccountsToInvokeFunction(ctx, mockFunctionID, mockVersionID, AuthorizeAccountsToInvokeFunctionRequest{})
+				return err
+			},
+			wantPath: "/nvcf/authorizations/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "UnAuthorizeAllExtraAccountsToInvokeFunction",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				return c.UnAuthorizeAllExtraAccountsToInvokeFunction(ctx, mockFunctionID, mockVersionID)
+			},
+			wantPath: "/nvcf/authorizations/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "GetFunctionAuthorization",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.GetFunctionAuthorization(ctx, mockFunctionID, mockVersionID)
+				return err
+			},
+			wantPath: "/nvcf/authorizations/functions/" + mockFunctionID + "/versions/" + mockVersionID,
+		},
+		{
+			name:         "CreateTelemetry",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.CreateTelemetry(ctx, CreateNvidiaCloudFunctionTelemetryRequest{})
+				return err
+			},
+			wantPath: "/nvcf/telemetries",
+		},
+		{
+			name:         "GetTelemetry",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.GetTelemetry(ctx, mockTelemetryID)
+				return err
+			},
+			wantPath: "/nvcf/telemetries/" + mockTelemetryID,
+		},
+		{
+			name:         "ListTelemetries",
+			responseCode: 200,
+			call: func(c *NVCFClient) error {
+				_, err := c.ListTelemetries(ctx)
+				return err
+			},
+			wantPath: "/nvcf/telemetries",
+		},
+		{
+			name:         "DeleteTelemetry",
+			responseCode: 204,
+			call: func(c *NVCFClient) error {
+				return c.DeleteTelemetry(ctx, mockTelemetryID)
+			},
+			wantPath: "/nvcf/telemetries/" + mockTelemetryID,
+		},
+	}
+
+	scopes := map[string]string{
+		"":       "/v2/orgs/" + mockOrg,
+		mockTeam: "/v2/orgs/" + mockOrg + "/teams/" + mockTeam,
+	}
+
+	for _, tt := range tests {
+		for team, scopePath := range scopes {
+			t.Run(fmt.Sprintf("%s_Team%q", tt.name, team), func(t *testing.T) {
+				var gotPath string
+				c := &NVCFClient{
+					NgcEndpoint: mockEndpoint,
+					NgcApiKey:   mockApiKey,
+					NgcOrg:      mockOrg,
+					NgcTeam:     team,
+					HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+						gotPath = req.URL.Path
+						return &http.Response{
+							StatusCode: tt.responseCode,
+							Header:     make(http.Header),
+							Body:       io.NopCloser(strings.NewReader("{}")),
+						}, nil
+					})},
+				}
+
+				assert.NoError(t, tt.call(c))
+				assert.Equal(t, scopePath+tt.wantPath, gotPath)
+			})
+		}
+	}
+}
+
+func TestNVCFClient_FindTelemetryByName(t *testing.T) {
+	t.Parallel()
+
+	var listTelemetriesResp ListNvidiaCloudFunctionTelemetryResponse
+	json.Unmarshal([]byte(mockTelemetryListInfo), &listTelemetriesResp)
+
+	mockAmbiguousTelemetryListInfo := `{"telemetries": [
+		{"telemetryId": "tel-1", "name": "test-telemetry"},
+		{"telemetryId": "tel-2", "name": "test-telemetry"}
+	]}`
+
+	tests := []struct {
+		name         string
+		telemetry    string
+		responseBody string
+		wantResp     *NvidiaCloudFunctionTelemetry
+		wantErr      string
+		wantNotFound bool
+	}{
+		{
+			name:         "UniqueName",
+			telemetry:    "test-telemetry-2",
+			responseBody: mockTelemetryListInfo,
+			wantResp:     &listTelemetriesResp.Telemetries[1],
+		},
+		{
+			name:         "AmbiguousName",
+			telemetry:    "test-telemetry",
+			responseBody: mockAmbiguousTelemetryListInfo,
+			wantErr:      "telemetry name test-telemetry is ambiguous, matched ids: tel-1, tel-2",
+		},
+		{
+			name:         "NameNotFound",
+			telemetry:    "test-telemetry-3",
+			responseBody: mockTelemetryListInfo,
+			wantErr:      "telemetry test-telemetry-3 not found",
+			wantNotFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				NgcTeam:     mockTeam,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(
+						t,
+						fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/telemetries", mockEndpoint, mockOrg, mockTeam),
+						http.MethodGet,
+						nvcfRequestHeaders,
+						nil,
+						tt.responseBody,
+						200,
+					),
+				},
+			}
+			gotResp, err := c.FindTelemetryByName(context.Background(), tt.telemetry)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, tt.wantNotFound, IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResp, gotResp)
+		})
+	}
+}
+
+func TestErrorResponse_Message(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		want       string
+	}{
+		{
+			name:       "RequestStatusFormat",
+			body:       mockErrorResponse,
+			statusCode: 400,
+			want:       mockErrorDetail + " (status code 400)",
+		},
+		{
+			name:       "ProblemFormat",
+			body:       `{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "Function not found"}`,
+			statusCode: 404,
+			want:       "Not Found: Function not found (status code 404)",
+		},
+		{
+			name:       "ProblemFormatWithoutDetail",
+			body:       `{"type": "about:blank", "title": "Forbidden", "status": 403}`,
+			statusCode: 403,
+			want:       "Forbidden (status code 403)",
+		},
+		{
+			name:       "ProblemFormatTakesPrecedence",
+			body:       `{"type": "about:blank", "title": "Conflict", "detail": "Version is deploying", "requestStatus": {"statusDescription": "ignored"}}`,
+			statusCode: 409,
+			want:       "Conflict: Version is deploying (status code 409)",
+		},
+		{
+			name:       "DetailWithoutType",
+			body:       `{"detail": "Invalid function configuration"}`,
+			statusCode: 400,
+			want:       "Invalid function configuration (status code 400)",
+		},
+		{
+			name:       "EmptyBody",
+			body:       `{}`,
+			statusCode: 502,
+			want:       "Bad Gateway (status code 502)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errResponse ErrorResponse
+			assert.NoError(t, json.Unmarshal([]byte(tt.body), &errResponse))
+			assert.Equal(t, tt.want, errResponse.Message(tt.statusCode))
+		})
+	}
+}
+
+func TestSendRequestUnexpectedContentType(t *testing.T) {
+	t.Parallel()
+
+	htmlBody := "<html><body>Please sign in</body></html>"
+
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header := make(http.Header)
+			header.Set("Content-Type", "text/html; charset=utf-8")
+			return &http.Response{
+				StatusCode: 200,
+				Header:     header,
+				Body:       io.NopCloser(strings.NewReader(htmlBody)),
+			}, nil
+		})},
+	}
+
+	_, err := c.ListTelemetries(context.Background())
+
+	assert.EqualError(t, err, "unexpected content type text/html; charset=utf-8. Response body: "+htmlBody)
+}
+
+func TestSendRequestResponseBodyTooLarge(t *testing.T) {
+	t.Parallel()
+
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(`{"telemetries": []}` + strings.Repeat(" ", maxResponseBodySize))),
+			}, nil
+		})},
+	}
+
+	_, err := c.ListTelemetries(context.Background())
+
+	assert.EqualError(t, err, fmt.Sprintf("response body exceeds the maximum size of %d bytes", maxResponseBodySize))
+}
+
+func TestIsJSONContentType(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isJSONContentType("application/json"))
+	assert.True(t, isJSONContentType("application/json; charset=utf-8"))
+	assert.True(t, isJSONContentType("application/problem+json"))
+	assert.False(t, isJSONContentType("text/html"))
+	assert.False(t, isJSONContentType(";;"))
+}
+
+func TestSendRequestQuotaExceeded(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		body              string
+		wantQuotaExceeded bool
+	}{
+		{
+			name:              "AllocatedGpuInstancesInUse",
+			body:              mockErrorResponse,
+			wantQuotaExceeded: true,
+		},
+		{
+			name:              "OtherValidationFailure",
+			body:              `{"requestStatus": {"statusCode": "INVALID_REQUEST", "statusDescription": "Validation failed - [Invalid instance type]"}}`,
+			wantQuotaExceeded: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Header:     make(http.Header),
+						Body:       io.NopCloser(strings.NewReader(tt.body)),
+					}, nil
+				})},
+			}
+
+			_, err := c.CreateNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID, CreateNvidiaCloudFunctionDeploymentRequest{})
+
+			assert.Error(t, err)
+			assert.Equal(t, tt.wantQuotaExceeded, errors.Is(err, ErrQuotaExceeded))
+			assert.False(t, IsNotFound(err))
+		})
+	}
+
+	// The message of the API is kept as is.
+	assert.EqualError(t, &quotaExceededError{message: mockErrorDetail}, mockErrorDetail)
+}
+
+func TestSendRequestConflict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		statusCode   int
+		body         string
+		wantConflict bool
+	}{
+		{
+			name:         "Conflict",
+			statusCode:   http.StatusConflict,
+			body:         `{"detail": "Version is deploying"}`,
+			wantConflict: true,
+		},
+		{
+			name:         "AlreadyExistsMessage",
+			statusCode:   http.StatusBadRequest,
+			body:         `{"detail": "Deployment for function version already exists"}`,
+			wantConflict: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: tt.statusCode,
+						Header:     make(http.Header),
+						Body:       io.NopCloser(strings.NewReader(tt.body)),
+					}, nil
+				})},
+			}
+
+			// Only the deployment creation looks at the message, other calls go by the status code.
+			_, err := c.UpdateNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID, UpdateNvidiaCloudFunctionDeploymentRequest{})
+
+			assert.Error(t, err)
+			assert.Equal(t, tt.wantConflict, IsConflict(err))
+		})
+	}
+}
+
+func TestNVCFClient_ApiVersion(t *testing.T) {
+	t.Parallel()
+
+	getFunctionVersionMockRespRaw := fmt.Sprintf(`{"function": %s}`, mockContainerBasedFunctionInfo)
+
+	tests := []struct {
+		name       string
+		apiVersion string
+		wantPath   string
+	}{
+		{
+			name:       "Default",
+			apiVersion: "",
+			wantPath:   fmt.Sprintf("/v2/orgs/%s/nvcf/functions/%s/versions/%s", mockOrg, mockFunctionID, mockVersionID),
+		},
+		{
+			name:       "Override",
+			apiVersion: "v3",
+			wantPath:   fmt.Sprintf("/v3/orgs/%s/nvcf/functions/%s/versions/%s", mockOrg, mockFunctionID, mockVersionID),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				ApiVersion:  tt.apiVersion,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(
+						t,
+						mockEndpoint+tt.wantPath,
+						http.MethodGet,
+						nvcfRequestHeaders,
+						nil,
+						getFunctionVersionMockRespRaw,
+						200,
+					),
+				},
+			}
+
+			_, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNVCFClient_FindActiveNvidiaCloudFunctionVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		responseBody  string
+		wantVersionID string
+		wantErr       string
+		wantNotFound  bool
+	}{
+		{
+			name: "SingleActiveVersion",
+			responseBody: `{"functions": [
+				{"id": "fn-1", "versionId": "ver-1", "status": "INACTIVE"},
+				{"id": "fn-1", "versionId": "ver-2", "status": "ACTIVE", "inferenceUrl": "/predict"},
+				{"id": "fn-1", "versionId": "ver-3", "status": "DEPLOYING"},
+				{"id": "fn-1", "versionId": "ver-4", "status": "ERROR"}
+			]}`,
+			wantVersionID: "ver-2",
+		},
+		{
+			name: "MultipleActiveVersions",
+			responseBody: `{"functions": [
+				{"id": "fn-1", "versionId": "ver-1", "status": "ACTIVE"},
+				{"id": "fn-1", "versionId": "ver-2", "status": "ACTIVE"}
+			]}`,
+			wantErr: "function fn-1 has multiple ACTIVE versions: ver-1, ver-2",
+		},
+		{
+			name: "NoActiveVersion",
+			responseBody: `{"functions": [
+				{"id": "fn-1", "versionId": "ver-1", "status": "INACTIVE"}
+			]}`,
+			wantErr:      "function fn-1 has no ACTIVE version",
+			wantNotFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				HttpClient: &http.Client{
+					Transport: GenerateHttpClientMockRoundTripper(
+						t,
+						fmt.Sprintf("%s/v2/orgs/%s/nvcf/functions/fn-1/versions", mockEndpoint, mockOrg),
+						http.MethodGet,
+						nvcfRequestHeaders,
+						nil,
+						tt.responseBody,
+						200,
+					),
+				},
+			}
+			gotResp, err := c.FindActiveNvidiaCloudFunctionVersion(context.Background(), "fn-1")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, tt.wantNotFound, IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantVersionID, gotResp.VersionID)
+			assert.Equal(t, "/predict", gotResp.InferenceURL)
+		})
+	}
+}
+
+func TestNVCFClient_ListNvidiaCloudFunctionVersionsCache(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantCalls int
+	}{
+		{
+			name:      "WithVersionsCache",
+			ctx:       WithVersionsCache(context.Background()),
+			wantCalls: 1,
+		},
+		{
+			name:      "WithoutVersionsCache",
+			ctx:       context.Background(),
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &countingRoundTripper{
+				responseBody: `{"functions": [{"id": "fn-1", "versionId": "ver-1", "status": "ACTIVE"}]}`,
+				responseCode: 200,
+			}
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				HttpClient:  &http.Client{Transport: rt},
+			}
+
+			listResp, err := c.ListNvidiaCloudFunctionVersions(tt.ctx, "fn-1")
+			assert.NoError(t, err)
+			assert.Len(t, listResp.Functions, 1)
+
+			activeResp, err := c.FindActiveNvidiaCloudFunctionVersion(tt.ctx, "fn-1")
+			assert.NoError(t, err)
+			assert.Equal(t, "ver-1", activeResp.VersionID)
+
+			assert.Equal(t, tt.wantCalls, rt.calls)
+		})
+	}
+}
+
+func TestNVCFClient_ListNvidiaCloudFunctionVersionsCacheSkipsFailures(t *testing.T) {
+	t.Parallel()
+
+	rt := &countingRoundTripper{responseBody: `{"detail": "mock error"}`, responseCode: 403}
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient:  &http.Client{Transport: rt},
+	}
+	ctx := WithVersionsCache(context.Background())
+
+	_, err := c.ListNvidiaCloudFunctionVersions(ctx, "fn-1")
+	assert.Error(t, err)
+	_, err = c.ListNvidiaCloudFunctionVersions(ctx, "fn-1")
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, rt.calls)
+}
+
+func TestNVCFClient_GetNvidiaCloudFunctionVersionActiveInstances(t *testing.T) {
+	t.Parallel()
+
+	getNvidiaCloudFunctionVersionMockRespRaw := fmt.Sprintf(`
+		{
+			"function": {
+				"id": "%[1]s",
+				"versionId": "%[2]s",
+				"name": "mock-container-function",
+				"status": "ACTIVE",
+				"activeInstances": [
+					{
+						"instanceId": "mock-instance-1",
+						"functionId": "%[1]s",
+						"functionVersionId": "%[2]s",
+						"instanceType": "gl40_1.br20_2xlarge",
+						"instanceStatus": "ACTIVE",
+						"gpu": "L40",
+						"backend": "GFN",
+						"location": "us-west-2",
+						"instanceCreatedAt": "2024-03-13T09:04:20Z",
+						"instanceUpdatedAt": "2024-03-13T09:10:00Z"
+					},
+					{
+						"instanceId": "mock-instance-2",
+						"functionId": "%[1]s",
+						"functionVersionId": "%[2]s",
+						"instanceType": "gl40_1.br20_2xlarge",
+						"instanceStatus": "STARTING",
+						"gpu": "L40",
+						"backend": "GFN",
+						"location": "us-east-1",
+						"instanceCreatedAt": "2024-03-13T09:05:20Z",
+						"instanceUpdatedAt": "2024-03-13T09:05:20Z"
+					}
+				]
+			}
+		}
+		`, mockFunctionID, mockVersionID)
+
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		HttpClient: &http.Client{
+			Transport: GenerateHttpClientMockRoundTripper(
+				t,
+				fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+				http.MethodGet,
+				nvcfRequestHeaders,
+				nil,
+				getNvidiaCloudFunctionVersionMockRespRaw,
+				200,
+			),
+		},
+	}
+
+	resp, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+	assert.Nil(t, err)
+
+	instances := resp.Function.ActiveInstances
+	assert.Len(t, instances, 2)
+	assert.Equal(t, "mock-instance-1", instances[0].InstanceID)
+	assert.Equal(t, "ACTIVE", instances[0].InstanceStatus)
+	assert.Equal(t, "us-west-2", instances[0].Location)
+	assert.Equal(t, time.Date(2024, 3, 13, 9, 10, 0, 0, time.UTC), instances[0].InstanceUpdatedAt)
+	assert.Equal(t, "mock-instance-2", instances[1].InstanceID)
+	assert.Equal(t, "STARTING", instances[1].InstanceStatus)
+	assert.Equal(t, "GFN", instances[1].Backend)
+	assert.Equal(t, mockVersionID, instances[1].FunctionVersionID)
+}
+
+func TestNVCFClient_SuccessStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		call     func(c *NVCFClient) error
+		accepted []int
+	}{
+		{
+			name: "GetNvidiaCloudFunctionVersion",
+			call: func(c *NVCFClient) error {
+				_, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+				return err
+			},
+			accepted: []int{http.StatusOK},
+		},
+		{
+			name: "ReadNvidiaCloudFunctionDeployment",
+			call: func(c *NVCFClient) error {
+				_, err := c.ReadNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID)
+				return err
+			},
+			accepted: []int{http.StatusOK, http.StatusNotFound},
+		},
+		{
+			name: "CreateNvidiaCloudFunction",
+			call: func(c *NVCFClient) error {
+				_, err := c.CreateNvidiaCloudFunction(context.Background(), "", CreateNvidiaCloudFunctionRequest{})
+				return err
+			},
+			accepted: []int{http.StatusOK},
+		},
+		{
+			name: "UpdateFunctionSecrets",
+			call: func(c *NVCFClient) error {
+				return c.UpdateFunctionSecrets(context.Background(), mockFunctionID, mockVersionID, UpdateNvidiaCloudFunctionSecretsRequest{})
+			},
+			accepted: []int{http.StatusNoContent},
+		},
+		{
+			name: "DeleteNvidiaCloudFunctionVersion",
+			call: func(c *NVCFClient) error {
+				return c.DeleteNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+			},
+			accepted: []int{http.StatusNoContent},
+		},
+		{
+			name: "DeleteNvidiaCloudFunctionDeployment",
+			call: func(c *NVCFClient) error {
+				_, err := c.DeleteNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID, false)
+				return err
+			},
+			accepted: []int{http.StatusOK},
+		},
+	}
+	for _, tt := range tests {
+		for _, statusCode := range []int{http.StatusOK, http.StatusNoContent, http.StatusNotFound, http.StatusBadRequest} {
+			t.Run(fmt.Sprintf("%s/%d", tt.name, statusCode), func(t *testing.T) {
+				c := &NVCFClient{
+					NgcEndpoint: mockEndpoint,
+					NgcApiKey:   mockApiKey,
+					NgcOrg:      mockOrg,
+					HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+						return &http.Response{
+							StatusCode: statusCode,
+							Header:     make(http.Header),
+							Body:       io.NopCloser(strings.NewReader("{}")),
+						}, nil
+					})},
+				}
+
+				err := tt.call(c)
+
+				assert.Equal(t, slices.Contains(tt.accepted, statusCode), err == nil, err)
+			})
+		}
+	}
+}
+
+func TestSendRequestEmbeddedError(t *testing.T) {
+	t.Parallel()
+
+	const errorBody = `{"requestStatus": {"statusCode": "INVALID_REQUEST", "statusDescription": "Validation failed - [Invalid instance type]"}}`
+
+	tests := []struct {
+		name    string
+		body    string
+		send    func(c *NVCFClient) error
+		wantErr string
+	}{
+		{
+			name: "CreateDeploymentErrorRequestStatus",
+			body: errorBody,
+			send: func(c *NVCFClient) error {
+				_, err := c.CreateNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID, CreateNvidiaCloudFunctionDeploymentRequest{})
+				return err
+			},
+			wantErr: "Validation failed - [Invalid instance type] (status code 200)",
+		},
+		{
+			name: "UpdateDeploymentErrorRequestStatus",
+			body: errorBody,
+			send: func(c *NVCFClient) error {
+				_, err := c.UpdateNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID, UpdateNvidiaCloudFunctionDeploymentRequest{})
+				return err
+			},
+			wantErr: "Validation failed - [Invalid instance type] (status code 200)",
+		},
+		{
+			name: "CreateDeploymentSuccessRequestStatus",
+			body: `{"requestStatus": {"statusCode": "SUCCESS"}, "deployment": {"deploymentId": "mock-deployment-id"}}`,
+			send: func(c *NVCFClient) error {
+				_, err := c.CreateNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID, CreateNvidiaCloudFunctionDeploymentRequest{})
+				return err
+			},
+		},
+		{
+			name: "CreateDeploymentWithoutRequestStatus",
+			body: mockFunctionDeploymentInfo,
+			send: func(c *NVCFClient) error {
+				_, err := c.CreateNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID, CreateNvidiaCloudFunctionDeploymentRequest{})
+				return err
+			},
+		},
+		{
+			// Other operations aren't checked.
+			name: "ReadDeploymentErrorRequestStatus",
+			body: errorBody,
+			send: func(c *NVCFClient) error {
+				_, err := c.ReadNvidiaCloudFunctionDeployment(context.Background(), mockFunctionID, mockVersionID)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     make(http.Header),
+						Body:       io.NopCloser(strings.NewReader(tt.body)),
+					}, nil
+				})},
+			}
+
+			err := tt.send(c)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSendRequestWithoutCredentials(t *testing.T) {
+	t.Parallel()
+
+	rt := &countingRoundTripper{responseBody: "{}", responseCode: 200}
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   "",
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		HttpClient:  &http.Client{Transport: rt},
+	}
+
+	_, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+
+	assert.ErrorIs(t, err, ErrNoCredentials)
+	assert.Equal(t, 0, rt.calls)
+}
+
+func TestSendRequestWithExtraHeaders(t *testing.T) {
+	t.Parallel()
+
+	var gotHeader http.Header
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		ExtraHeaders: map[string]string{
+			"X-Routing-Tag": "mock-routing-tag",
+			"authorization": "Bearer mock-override",
+		},
+		HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			gotHeader = req.Header
+			return &http.Response{
+				StatusCode: 200,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(`{"function": {}}`)),
+			}, nil
+		})},
+	}
+
+	_, err := c.GetNvidiaCloudFunctionVersion(context.Background(), mockFunctionID, mockVersionID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mock-routing-tag", gotHeader.Get("X-Routing-Tag"))
+	// Reserved headers are never overridden.
+	assert.Equal(t, "Bearer "+mockApiKey, gotHeader.Get("Authorization"))
+	assert.Equal(t, "application/json", gotHeader.Get("Content-Type"))
+}
+
+func TestNVCFClient_WaitingDeploymentCompletedFailureReason(t *testing.T) {
+	t.Parallel()
+
+	mockFunctionDeploymentFailedWithReasonInfo := fmt.Sprintf(`
+		{
+			"deployment" : {
+				"deploymentId": "%s",
+				"functionId": "%s",
+				"functionVersionId": "%s",
+				"functionStatus": "FAILED",
+				"healthInfo": [
+					{
+						"sisRequestId": "mock-sis-request-id",
+						"gpu": "L40",
+						"backend": "GFN",
+						"instanceType": "gl40_1.br20_2xlarge",
+						"error": "Failed to pull image nvcr.io/mock-org/mock-image:1.0.0"
+					}
+				],
+				"deploymentSpecifications": [%s]
+			}
+		}
+		`, mockDeploymentID, mockFunctionID, mockVersionID, mockDeploymentSpecification)
+
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		HttpClient: &http.Client{
+			Transport: GenerateHttpClientMockRoundTripper(
+				t,
+				fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/deployments/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+				http.MethodGet,
+				nvcfRequestHeaders,
+				nil,
+				mockFunctionDeploymentFailedWithReasonInfo,
+				200,
+			),
+		},
+	}
+
+	err := c.WaitingDeploymentCompleted(context.Background(), mockFunctionID, mockVersionID)
+
+	assert.EqualError(t, err, "unexpected status FAILED: L40/gl40_1.br20_2xlarge: Failed to pull image nvcr.io/mock-org/mock-image:1.0.0")
+}
+
+func TestDeploymentFailureReason(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", deploymentFailureReason(nil))
+	assert.Equal(t, "OOMKilled", deploymentFailureReason("OOMKilled"))
+	assert.Equal(t, "OOMKilled", deploymentFailureReason(map[string]interface{}{"error": "OOMKilled"}))
+	assert.Equal(t, "", deploymentFailureReason(map[string]interface{}{"gpu": "L40"}))
+	assert.Equal(t, "L40/gl40_1.br20_2xlarge: OOMKilled; H100/DGX-CLOUD.GPU.H100_1x: Failed to pull image", deploymentFailureReason([]interface{}{
+		map[string]interface{}{"gpu": "L40", "instanceType": "gl40_1.br20_2xlarge", "error": "OOMKilled"},
+		map[string]interface{}{"gpu": "H100", "instanceType": "DGX-CLOUD.GPU.H100_1x", "error": "Failed to pull image"},
+	}))
+}
+
+// fakeClock records the requested waits and fires them immediately, unless blocked,
+// so the polling and retry loops run without sleeping.
+type fakeClock struct {
+	waits  []time.Duration
+	block  bool
+	onWait func()
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	if c.onWait != nil {
+		c.onWait()
+	}
+
+	ch := make(chan time.Time, 1)
+	if !c.block {
+		ch <- time.Time{}
+	}
+	return ch
+}
+
+func TestNVCFClient_WaitingDeploymentCompletedPolling(t *testing.T) {
+	t.Parallel()
+
+	deploying := strings.Replace(mockFunctionDeploymentActiveInfo, `"ACTIVE"`, `"DEPLOYING"`, 1)
+	reads := 0
+	clock := &fakeClock{}
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		Clock:       clock,
+		HttpClient: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				reads++
+				body := deploying
+				if reads == 3 {
+					body = mockFunctionDeploymentActiveInfo
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+			}),
+		},
+	}
+
+	err := c.WaitingDeploymentCompleted(context.Background(), mockFunctionID, mockVersionID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, reads)
+	assert.Equal(t, []time.Duration{deploymentPollInterval, deploymentPollInterval}, clock.waits)
+}
+
+func TestNVCFClient_WaitingDeploymentCompletedTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The deadline passes while waiting for the next poll, which never fires.
+	clock := &fakeClock{block: true, onWait: cancel}
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		Clock:       clock,
+		HttpClient: &http.Client{
+			Transport: GenerateHttpClientMockRoundTripper(
+				t,
+				fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/deployments/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+				http.MethodGet,
+				nvcfRequestHeaders,
+				nil,
+				mockFunctionDeploymentInfo,
+				200,
+			),
+		},
+	}
+
+	err := c.WaitingDeploymentCompleted(ctx, mockFunctionID, mockVersionID)
+
+	assert.EqualError(t, err, "timeout occurred")
+	assert.Equal(t, []time.Duration{deploymentPollInterval}, clock.waits)
+}
+
+func TestNVCFClient_WaitingMinInstancesActive(t *testing.T) {
+	t.Parallel()
+
+	instance := `{"instanceId": "mock-instance-%d", "instanceStatus": "%s"}`
+	// The instances come up one after the other.
+	polls := [][]string{
+		{fmt.Sprintf(instance, 1, "STARTING")},
+		{fmt.Sprintf(instance, 1, "ACTIVE"), fmt.Sprintf(instance, 2, "STARTING"), fmt.Sprintf(instance, 3, "STARTING")},
+		{fmt.Sprintf(instance, 1, "ACTIVE"), fmt.Sprintf(instance, 2, "ACTIVE"), fmt.Sprintf(instance, 3, "STARTING")},
+		{fmt.Sprintf(instance, 1, "ACTIVE"), fmt.Sprintf(instance, 2, "ACTIVE"), fmt.Sprintf(instance, 3, "ACTIVE")},
+	}
+	reads := 0
+	clock := &fakeClock{}
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		Clock:       clock,
+		HttpClient: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, fmt.Sprintf("/v2/orgs/%s/teams/%s/nvcf/functions/%s/versions/%s", mockOrg, mockTeam, mockFunctionID, mockVersionID), req.URL.Path)
+				body := fmt.Sprintf(`{"function": {"id": "%s", "versionId": "%s", "activeInstances": [%s]}}`, mockFunctionID, mockVersionID, strings.Join(polls[reads], ","))
+				reads++
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+			}),
+		},
+	}
+
+	err := c.WaitingMinInstancesActive(context.Background(), mockFunctionID, mockVersionID, []NvidiaCloudFunctionDeploymentSpecification{
+		{Gpu: "L40", MinInstances: 2},
+		{Gpu: "H100", MinInstances: 1},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, reads)
+	assert.Equal(t, []time.Duration{deploymentPollInterval, deploymentPollInterval, deploymentPollInterval}, clock.waits)
+}
+
+func TestNVCFClient_WaitingMinInstancesActiveTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &fakeClock{block: true, onWait: cancel}
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		Clock:       clock,
+		HttpClient: &http.Client{
+			Transport: GenerateHttpClientMockRoundTripper(
+				t,
+				fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+				http.MethodGet,
+				nvcfRequestHeaders,
+				nil,
+				`{"function": {"activeInstances": [{"instanceId": "mock-instance-1", "instanceStatus": "ACTIVE"}]}}`,
+				200,
+			),
+		},
+	}
+
+	err := c.WaitingMinInstancesActive(ctx, mockFunctionID, mockVersionID, []NvidiaCloudFunctionDeploymentSpecification{{Gpu: "L40", MinInstances: 2}})
+
+	assert.EqualError(t, err, "timeout occurred, 1 of 2 instances active")
+	assert.Equal(t, []time.Duration{deploymentPollInterval}, clock.waits)
+}
+
+func TestSendRequestRetryTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rt := &countingRoundTripper{responseBody: `{}`, responseCode: http.StatusServiceUnavailable}
+	clock := &fakeClock{block: true, onWait: cancel}
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient:  &http.Client{Transport: rt},
+		RetryBudget: NewRetryBudget(time.Hour),
+		Clock:       clock,
+	}
+
+	_, err := c.ReadNvidiaCloudFunctionDeployment(ctx, mockFunctionID, mockVersionID)
+
+	assert.EqualError(t, err, "timeout occurred")
+	assert.Equal(t, 1, rt.calls)
+	assert.Equal(t, []time.Duration{retryDelay(0)}, clock.waits)
+}
+
+func TestNVCFClient_WaitingDeploymentDeleted(t *testing.T) {
+	t.Parallel()
+
+	reads := 0
+	clock := &fakeClock{}
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		Clock:       clock,
+		HttpClient: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				reads++
+				// The deployment is terminating for two reads, then gone.
+				if reads < 3 {
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(mockFunctionDeploymentInfo))}, nil
+				}
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"detail": "Not found"}`))}, nil
+			}),
+		},
+	}
+
+	err := c.WaitingDeploymentDeleted(context.Background(), mockFunctionID, mockVersionID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, reads)
+	assert.Equal(t, []time.Duration{deploymentDeletePollInterval, deploymentDeletePollInterval}, clock.waits)
+}
+
+func TestNVCFClient_WaitingDeploymentDeletedTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &fakeClock{block: true, onWait: cancel}
+	c := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		NgcTeam:     mockTeam,
+		Clock:       clock,
+		HttpClient: &http.Client{
+			Transport: GenerateHttpClientMockRoundTripper(
+				t,
+				fmt.Sprintf("%s/v2/orgs/%s/teams/%s/nvcf/deployments/functions/%s/versions/%s", mockEndpoint, mockOrg, mockTeam, mockFunctionID, mockVersionID),
+				http.MethodGet,
+				nvcfRequestHeaders,
+				nil,
+				mockFunctionDeploymentInfo,
+				200,
+			),
+		},
+	}
+
+	err := c.WaitingDeploymentDeleted(ctx, mockFunctionID, mockVersionID)
+
+	assert.EqualError(t, err, "timeout occurred")
+}
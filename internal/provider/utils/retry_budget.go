@@ -0,0 +1,105 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+package utils
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+var retryBaseDelay = 500 * time.Millisecond
+var retryMaxDelay = 30 * time.Second
+
+// RetryBudget bounds the total time spent waiting between retries. A single
+// budget is shared by every request of a provider instance, so the aggregate
+// retry delay of an apply stays bounded no matter how many resources it touches.
+type RetryBudget struct {
+	mu        sync.Mutex
+	remaining time.Duration
+}
+
+func NewRetryBudget(total time.Duration) *RetryBudget {
+	return &RetryBudget{remaining: total}
+}
+
+// Reserve deducts wait from the budget. It returns false, leaving the budget
+// untouched, when the remaining budget can't cover the wait.
+func (b *RetryBudget) Reserve(wait time.Duration) bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if wait > b.remaining {
+		return false
+	}
+	b.remaining -= wait
+	return true
+}
+
+func (b *RetryBudget) Remaining() time.Duration {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.remaining
+}
+
+// retryDelay returns the exponential backoff delay before the given retry attempt.
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for i := 0; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+// isRetryableResponse reports whether a request failed in a way that is worth retrying.
+func isRetryableResponse(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableRequest reports whether a failed request can be sent again. Requests failing ambiguously may
+// have been processed already, so non-idempotent methods are never retried: retrying a create blindly may
+// e.g. create a second function version. Rate limited requests weren't processed at all.
+func isRetryableRequest(method string, response *http.Response, err error) bool {
+	if !isRetryableResponse(response, err) {
+		return false
+	}
+
+	if err == nil && response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
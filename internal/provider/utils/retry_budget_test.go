@@ -0,0 +1,263 @@
+//  SPDX-FileCopyrightText: Copyright (c) 2024 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+//  SPDX-License-Identifier: LicenseRef-NvidiaProprietary
+
+//  NVIDIA CORPORATION, its affiliates and licensors retain all intellectual
+//  property and proprietary rights in and to this material, related
+//  documentation and any modifications thereto. Any use, reproduction,
+//  disclosure or distribution of this material and related documentation
+//  without an express license agreement from NVIDIA CORPORATION or
+//  its affiliates is strictly prohibited.
+
+//go:build unittest
+// +build unittest
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingRoundTripper replies with a fresh response for every call and counts the calls.
+type countingRoundTripper struct {
+	calls        int
+	responseBody string
+	responseCode int
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.calls++
+	return &http.Response{
+		StatusCode: rt.responseCode,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(rt.responseBody)),
+	}, nil
+}
+
+func TestRetryBudget_Reserve(t *testing.T) {
+	t.Parallel()
+
+	budget := NewRetryBudget(time.Second)
+
+	assert.True(t, budget.Reserve(600*time.Millisecond))
+	assert.Equal(t, 400*time.Millisecond, budget.Remaining())
+	assert.False(t, budget.Reserve(600*time.Millisecond))
+	assert.Equal(t, 400*time.Millisecond, budget.Remaining())
+	assert.True(t, budget.Reserve(400*time.Millisecond))
+	assert.False(t, budget.Reserve(time.Nanosecond))
+
+	var nilBudget *RetryBudget
+	assert.False(t, nilBudget.Reserve(0))
+	assert.Equal(t, time.Duration(0), nilBudget.Remaining())
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, retryBaseDelay, retryDelay(0))
+	assert.Equal(t, 2*retryBaseDelay, retryDelay(1))
+	assert.Equal(t, 4*retryBaseDelay, retryDelay(2))
+	assert.Equal(t, retryMaxDelay, retryDelay(100))
+}
+
+func TestSendRequestStopsRetryingWhenBudgetExhausted(t *testing.T) {
+	t.Parallel()
+
+	rt := &countingRoundTripper{
+		responseBody: `{"requestStatus": {"statusCode": "SERVICE_UNAVAILABLE", "statusDescription": "backend degraded"}}`,
+		responseCode: http.StatusServiceUnavailable,
+	}
+
+	// Covers the first backoff only, the second one exceeds what is left.
+	budget := NewRetryBudget(retryBaseDelay + retryBaseDelay/2)
+
+	client := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient:  &http.Client{Transport: rt},
+		RetryBudget: budget,
+	}
+
+	err := client.sendRequest(
+		context.Background(),
+		fmt.Sprintf("%s/v2/orgs/%s/nvcf/functions", mockEndpoint, mockOrg),
+		http.MethodGet,
+		nil,
+		nil,
+		map[int]bool{200: true},
+		nil,
+	)
+
+	assert.EqualError(t, err, "backend degraded (status code 503)")
+	assert.Equal(t, 2, rt.calls)
+	assert.Equal(t, retryBaseDelay/2, budget.Remaining())
+
+	// The budget is shared, so a later request doesn't get a fresh allowance.
+	rt.calls = 0
+	err = client.sendRequest(
+		context.Background(),
+		fmt.Sprintf("%s/v2/orgs/%s/nvcf/functions", mockEndpoint, mockOrg),
+		http.MethodGet,
+		nil,
+		nil,
+		map[int]bool{200: true},
+		nil,
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, rt.calls)
+}
+
+func TestSendRequestRetriesTransientFailure(t *testing.T) {
+	t.Parallel()
+
+	rt := &countingRoundTripper{responseBody: `{}`, responseCode: http.StatusBadGateway}
+
+	client := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if rt.calls == 1 {
+				rt.responseCode = http.StatusOK
+			}
+			return rt.RoundTrip(req)
+		})},
+		RetryBudget: NewRetryBudget(time.Minute),
+	}
+
+	err := client.sendRequest(
+		context.Background(),
+		fmt.Sprintf("%s/v2/orgs/%s/nvcf/functions", mockEndpoint, mockOrg),
+		http.MethodGet,
+		nil,
+		nil,
+		map[int]bool{200: true},
+		nil,
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rt.calls)
+}
+
+func TestSendRequestRetriesHungRequestAfterRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	client := &NVCFClient{
+		NgcEndpoint: mockEndpoint,
+		NgcApiKey:   mockApiKey,
+		NgcOrg:      mockOrg,
+		HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				// Hang until the per-request timeout cancels the call.
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(`{}`)),
+			}, nil
+		})},
+		RetryBudget:    NewRetryBudget(time.Minute),
+		RequestTimeout: 50 * time.Millisecond,
+	}
+
+	// The operation deadline is far longer than the request timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	err := client.sendRequest(
+		ctx,
+		fmt.Sprintf("%s/v2/orgs/%s/nvcf/functions", mockEndpoint, mockOrg),
+		http.MethodGet,
+		nil,
+		nil,
+		map[int]bool{200: true},
+		nil,
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestSendRequestRetryClassification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		responseCode int
+		transportErr bool
+		wantCalls    int
+	}{
+		// Non-idempotent requests failing with a retryable status may have been processed already.
+		{name: "PostBadGateway", method: http.MethodPost, responseCode: http.StatusBadGateway, wantCalls: 1},
+		{name: "PostServiceUnavailable", method: http.MethodPost, responseCode: http.StatusServiceUnavailable, wantCalls: 1},
+		{name: "PostGatewayTimeout", method: http.MethodPost, responseCode: http.StatusGatewayTimeout, wantCalls: 1},
+		{name: "PostTransportError", method: http.MethodPost, transportErr: true, wantCalls: 1},
+		{name: "PatchBadGateway", method: http.MethodPatch, responseCode: http.StatusBadGateway, wantCalls: 1},
+		{name: "PostTooManyRequests", method: http.MethodPost, responseCode: http.StatusTooManyRequests, wantCalls: 2},
+		{name: "PutBadGateway", method: http.MethodPut, responseCode: http.StatusBadGateway, wantCalls: 2},
+		{name: "DeleteBadGateway", method: http.MethodDelete, responseCode: http.StatusBadGateway, wantCalls: 2},
+		{name: "GetTransportError", method: http.MethodGet, transportErr: true, wantCalls: 2},
+		// The API key never changes during a run, sending it again can't succeed.
+		{name: "GetUnauthorized", method: http.MethodGet, responseCode: http.StatusUnauthorized, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := &countingRoundTripper{responseBody: `{}`, responseCode: tt.responseCode}
+
+			client := &NVCFClient{
+				NgcEndpoint: mockEndpoint,
+				NgcApiKey:   mockApiKey,
+				NgcOrg:      mockOrg,
+				HttpClient: &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					if tt.transportErr && rt.calls == 0 {
+						rt.calls++
+						return nil, errors.New("connection reset by peer")
+					}
+					if rt.calls == 1 {
+						rt.responseCode = http.StatusOK
+					}
+					return rt.RoundTrip(req)
+				})},
+				RetryBudget: NewRetryBudget(time.Minute),
+			}
+
+			err := client.sendRequest(
+				context.Background(),
+				fmt.Sprintf("%s/v2/orgs/%s/nvcf/functions", mockEndpoint, mockOrg),
+				tt.method,
+				nil,
+				nil,
+				map[int]bool{200: true},
+				nil,
+			)
+
+			assert.Equal(t, tt.wantCalls == 2, err == nil, err)
+			assert.Equal(t, tt.wantCalls, rt.calls)
+		})
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}